@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+var errNotStorageExtension = errors.New("extension is not a storage extension")
+
+// targetState is the per-target position persisted between polls.
+type targetState struct {
+	// Latest record timestamp seen
+	LastTimestamp time.Time `json:"last_timestamp"`
+
+	// Last non-empty pagination cursor returned by the target
+	LastCursor string `json:"last_cursor"`
+
+	// Whether the page of LastCursor has already been consumed, because the
+	// target returned no newer cursor
+	LastCursorFetched bool `json:"last_cursor_fetched"`
+
+	// ID of the last record received
+	LastID string `json:"last_id"`
+
+	// Time of the last poll, successful or not
+	LastPollTime time.Time `json:"last_poll_time"`
+
+	// Time of the last successful poll
+	LastSuccessTime time.Time `json:"last_success_time"`
+}
+
+// stateStore keeps target states in memory and, when a storage client is
+// set, persists them across restarts.
+type stateStore struct {
+	mu     sync.Mutex
+	client storage.Client
+	states map[string]targetState
+}
+
+// newStateStore creates an in-memory state store.
+func newStateStore() *stateStore {
+	return &stateStore{states: map[string]targetState{}}
+}
+
+// getStorageClient resolves the storage extension from the host and opens a client for the receiver.
+func getStorageClient(ctx context.Context, host component.Host, storageID component.ID, receiverID component.ID) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension %q not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("%q: %w", storageID, errNotStorageExtension)
+	}
+
+	return storageExt.GetClient(ctx, component.KindReceiver, receiverID, "")
+}
+
+// setClient attaches a storage client and drops states cached before it was available.
+func (s *stateStore) setClient(client storage.Client) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.client = client
+	s.states = map[string]targetState{}
+}
+
+// get returns the state of the target, loading it from storage on first access.
+func (s *stateStore) get(ctx context.Context, key string) (targetState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if state, ok := s.states[key]; ok {
+		return state, nil
+	}
+
+	var state targetState
+	if s.client != nil {
+		data, err := s.client.Get(ctx, key)
+		if err != nil {
+			return state, fmt.Errorf("failed to load state: %w", err)
+		}
+		if data != nil {
+			if err := json.Unmarshal(data, &state); err != nil {
+				return state, fmt.Errorf("failed to decode state: %w", err)
+			}
+		}
+	}
+
+	s.states[key] = state
+	return state, nil
+}
+
+// cached returns the in-memory state of the target without loading it from storage.
+func (s *stateStore) cached(key string) (targetState, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.states[key]
+	return state, ok
+}
+
+// put stores the state of the target.
+func (s *stateStore) put(ctx context.Context, key string, state targetState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.states[key] = state
+	if s.client == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	if err := s.client.Set(ctx, key, data); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	return nil
+}
+
+// close releases the storage client.
+func (s *stateStore) close(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.client == nil {
+		return nil
+	}
+
+	err := s.client.Close(ctx)
+	s.client = nil
+	return err
+}
+
+// stateKey returns a storage key that is stable for the same target config.
+func stateKey(target *targetConfig) string {
+	sum := sha256.Sum256([]byte(target.Method + "\n" + target.Endpoint + "\n" + target.Body))
+	return "target." + hex.EncodeToString(sum[:16])
+}
+
+// seenFilter drops the records a previous poll already emitted when a poll
+// fetches the page of the last cursor again. With id_field, the records up to
+// and including the last ID are dropped; otherwise, with a timestamp field,
+// the records no newer than the last timestamp. Only the first records passed
+// to the filter are considered. A nil seenFilter drops nothing.
+type seenFilter struct {
+	target        *targetConfig
+	extract       func(path string, raw interface{}) interface{}
+	lastID        string
+	lastTimestamp time.Time
+	done          bool
+}
+
+// newSeenFilter creates a filter for the first page of a poll, or returns nil
+// when the poll does not refetch an already consumed page or the records
+// cannot be matched.
+func newSeenFilter(target *targetConfig, state targetState, extract func(path string, raw interface{}) interface{}) *seenFilter {
+	if !state.LastCursorFetched || state.LastCursor == "" {
+		return nil
+	}
+
+	f := &seenFilter{target: target, extract: extract}
+	switch {
+	case target.IDField != "" && state.LastID != "":
+		f.lastID = state.LastID
+	case target.Timestamp != nil && !state.LastTimestamp.IsZero():
+		f.lastTimestamp = state.LastTimestamp
+	default:
+		return nil
+	}
+
+	return f
+}
+
+// filter removes the already emitted records from logs.
+func (f *seenFilter) filter(logs plog.Logs) {
+	if f == nil || f.done || logs.LogRecordCount() == 0 {
+		return
+	}
+	f.done = true
+
+	// Without the last ID in the page, nothing can be told apart
+	if f.lastID != "" && !f.containsLastID(logs) {
+		return
+	}
+
+	passed := false
+	resourceLogs := logs.ResourceLogs()
+	for i := 0; i < resourceLogs.Len(); i++ {
+		scopeLogs := resourceLogs.At(i).ScopeLogs()
+		for j := 0; j < scopeLogs.Len(); j++ {
+			scopeLogs.At(j).LogRecords().RemoveIf(func(record plog.LogRecord) bool {
+				if f.lastID == "" {
+					return !record.Timestamp().AsTime().After(f.lastTimestamp)
+				}
+				if passed {
+					return false
+				}
+				passed = f.recordID(record) == f.lastID
+				return true
+			})
+		}
+	}
+}
+
+// containsLastID reports whether a record of logs has the last ID.
+func (f *seenFilter) containsLastID(logs plog.Logs) bool {
+	resourceLogs := logs.ResourceLogs()
+	for i := 0; i < resourceLogs.Len(); i++ {
+		scopeLogs := resourceLogs.At(i).ScopeLogs()
+		for j := 0; j < scopeLogs.Len(); j++ {
+			records := scopeLogs.At(j).LogRecords()
+			for k := 0; k < records.Len(); k++ {
+				if f.recordID(records.At(k)) == f.lastID {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// recordID returns the id_field value of a record body.
+func (f *seenFilter) recordID(record plog.LogRecord) string {
+	if id := f.extract(f.target.IDField, record.Body().AsRaw()); id != nil {
+		return fmt.Sprintf("%v", id)
+	}
+	return ""
+}
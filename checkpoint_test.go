@@ -0,0 +1,389 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+// memoryStorageClient is an in-memory storage.Client used for tests.
+type memoryStorageClient struct {
+	mu     sync.Mutex
+	data   map[string][]byte
+	closed bool
+}
+
+func newMemoryStorageClient() *memoryStorageClient {
+	return &memoryStorageClient{data: map[string][]byte{}}
+}
+
+func (c *memoryStorageClient) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.data[key], nil
+}
+
+func (c *memoryStorageClient) Set(_ context.Context, key string, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data[key] = value
+	return nil
+}
+
+func (c *memoryStorageClient) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.data, key)
+	return nil
+}
+
+func (c *memoryStorageClient) Batch(ctx context.Context, ops ...*storage.Operation) error {
+	for _, op := range ops {
+		var err error
+		switch op.Type {
+		case storage.Get:
+			op.Value, err = c.Get(ctx, op.Key)
+		case storage.Set:
+			err = c.Set(ctx, op.Key, op.Value)
+		case storage.Delete:
+			err = c.Delete(ctx, op.Key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *memoryStorageClient) Close(_ context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+// memoryStorageExtension hands out a shared memoryStorageClient.
+type memoryStorageExtension struct {
+	client *memoryStorageClient
+}
+
+func (e *memoryStorageExtension) Start(context.Context, component.Host) error { return nil }
+
+func (e *memoryStorageExtension) Shutdown(context.Context) error { return nil }
+
+func (e *memoryStorageExtension) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return e.client, nil
+}
+
+// extensionsHost is a component.Host exposing a fixed set of extensions.
+type extensionsHost map[component.ID]component.Component
+
+func (h extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h
+}
+
+func TestStateStore_PersistsAcrossInstances(t *testing.T) {
+	ctx := context.Background()
+	client := newMemoryStorageClient()
+	want := targetState{LastTimestamp: time.Date(2025, 10, 15, 10, 0, 0, 0, time.UTC), LastCursor: "c42", LastID: "7"}
+
+	first := newStateStore()
+	first.setClient(client)
+	require.NoError(t, first.put(ctx, "target.a", want))
+	require.NoError(t, first.close(ctx))
+	assert.True(t, client.closed)
+
+	second := newStateStore()
+	second.setClient(client)
+	got, err := second.get(ctx, "target.a")
+	require.NoError(t, err)
+	assert.True(t, want.LastTimestamp.Equal(got.LastTimestamp))
+	assert.Equal(t, want.LastCursor, got.LastCursor)
+	assert.Equal(t, want.LastID, got.LastID)
+
+	empty, err := second.get(ctx, "target.b")
+	require.NoError(t, err)
+	assert.Equal(t, targetState{}, empty)
+}
+
+func TestLogsReceiver_Start_StorageExtension(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
+
+	r := newLogsReceiver(&Config{StorageID: &storageID}, settings, &testLogsSink{})
+	require.Error(t, r.Start(context.Background(), extensionsHost{}))
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	client := newMemoryStorageClient()
+	r = newLogsReceiver(&Config{CollectionInterval: time.Hour, StorageID: &storageID}, settings, &testLogsSink{})
+	require.NoError(t, r.Start(context.Background(), extensionsHost{storageID: &memoryStorageExtension{client: client}}))
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.True(t, client.closed)
+}
+
+func TestLogsReceiver_CheckpointResume(t *testing.T) {
+	var mu sync.Mutex
+	var cursors []string
+	var appended atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		after := r.URL.Query().Get("after")
+		mu.Lock()
+		cursors = append(cursors, after)
+		mu.Unlock()
+
+		// the last page returns no cursor and gains a record between polls
+		next := map[string]string{"": "c1", "c1": "c2", "c2": ""}[after]
+		page := after
+		if page == "" {
+			page = "c0"
+		}
+		records := fmt.Sprintf(`{"id":"%s-1","ts":"2025-10-15T10:00:00Z"},{"id":"%s-2","ts":"2025-10-15T11:00:00Z"}`, page, page)
+		if after == "c2" && appended.Load() {
+			records += `,{"id":"c2-3","ts":"2025-10-15T12:00:00Z"}`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"data":[%s],"next":%q}`, records, next)
+	}))
+	defer srv.Close()
+
+	newTarget := func() *targetConfig {
+		target := &targetConfig{
+			ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL},
+			RecordsPath:  "data",
+			IDField:      "id",
+			Timestamp:    &timestampConfig{Field: "ts"},
+			Pagination:   &paginationConfig{Mode: paginationModeCursor, CursorPath: "next", CursorParam: "after"},
+		}
+		require.NoError(t, target.Validate())
+		return target
+	}
+
+	ctx := context.Background()
+	client := newMemoryStorageClient()
+	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
+
+	firstSink := &testLogsSink{}
+	first := newLogsReceiver(&Config{}, settings, firstSink)
+	first.state.setClient(client)
+	target := newTarget()
+	require.NoError(t, first.pollTarget(ctx, target))
+	assert.Equal(t, 6, recordCount(firstSink))
+
+	state, err := first.state.get(ctx, stateKey(target))
+	require.NoError(t, err)
+	assert.Equal(t, "c2", state.LastCursor)
+	assert.True(t, state.LastCursorFetched)
+	assert.Equal(t, "c2-2", state.LastID)
+	assert.True(t, time.Date(2025, 10, 15, 11, 0, 0, 0, time.UTC).Equal(state.LastTimestamp))
+
+	// A new receiver instance, as after a restart, resumes from the stored
+	// cursor and emits none of the records of the refetched page again
+	secondSink := &testLogsSink{}
+	second := newLogsReceiver(&Config{}, settings, secondSink)
+	second.state.setClient(client)
+	require.NoError(t, second.pollTarget(ctx, newTarget()))
+	assert.Equal(t, 0, recordCount(secondSink))
+
+	// A record added to the page since is emitted on its own
+	appended.Store(true)
+	require.NoError(t, second.pollTarget(ctx, newTarget()))
+	require.Equal(t, 1, recordCount(secondSink))
+	id, _ := secondSink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Map().Get("id")
+	assert.Equal(t, "c2-3", id.Str())
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"", "c1", "c2", "c2", "c2"}, cursors)
+}
+
+func TestLogsReceiver_CheckpointResumeNumericIDs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the second page is the last one
+		body := `{"data":[{"id":1000001},{"id":1000002}],"next":"c1"}`
+		if r.URL.Query().Get("after") == "c1" {
+			body = `{"data":[{"id":2000001},{"id":2000002}],"next":""}`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{
+		ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL},
+		RecordsPath:  "data",
+		IDField:      "id",
+		Pagination:   &paginationConfig{Mode: paginationModeCursor, CursorPath: "next", CursorParam: "after"},
+	}
+	require.NoError(t, target.Validate())
+
+	ctx := context.Background()
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), sink)
+	r.state.setClient(newMemoryStorageClient())
+	require.NoError(t, r.pollTarget(ctx, target))
+	assert.Equal(t, 4, recordCount(sink))
+
+	state, err := r.state.get(ctx, stateKey(target))
+	require.NoError(t, err)
+	assert.Equal(t, "2000002", state.LastID)
+
+	// the refetched last page is recognized by its numeric ids
+	require.NoError(t, r.pollTarget(ctx, target))
+	assert.Equal(t, 4, recordCount(sink))
+}
+
+func TestLogsReceiver_CheckpointTimestampExtractedOnly(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"ts":"2020-01-01T00:00:00Z"},{"msg":"no ts"},{"ts":"not a time"}]`))
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{
+		ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL},
+		Timestamp:    &timestampConfig{Field: "ts"},
+	}
+	require.NoError(t, target.Validate())
+
+	ctx := context.Background()
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), &testLogsSink{})
+	r.state.setClient(newMemoryStorageClient())
+	require.NoError(t, r.pollTarget(ctx, target))
+
+	// records stamped with the poll time do not move the last timestamp
+	state, err := r.state.get(ctx, stateKey(target))
+	require.NoError(t, err)
+	assert.True(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Equal(state.LastTimestamp), state.LastTimestamp)
+}
+
+func TestLogsReceiver_CheckpointLastIDFormats(t *testing.T) {
+	tests := []struct {
+		format string
+		body   string
+	}{
+		{format: "json", body: `[{"id":1},{"id":7}]`},
+		{format: "ndjson", body: "{\"id\":1}\n{\"id\":7}\n"},
+		{format: "csv", body: "id,msg\n1,a\n7,b\n"},
+		{format: "xml", body: "<logs><log><id>1</id></log><log><id>7</id></log></logs>"},
+		{format: "logfmt", body: "id=1 msg=a\nid=7 msg=b\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}, Format: tt.format, IDField: "id"}
+			require.NoError(t, target.Validate())
+
+			ctx := context.Background()
+			r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), &testLogsSink{})
+			r.state.setClient(newMemoryStorageClient())
+			require.NoError(t, r.pollTarget(ctx, target))
+
+			state, err := r.state.get(ctx, stateKey(target))
+			require.NoError(t, err)
+			assert.Equal(t, "7", state.LastID)
+		})
+	}
+}
+
+func TestSeenFilter(t *testing.T) {
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), nil)
+	ts := func(hour int) time.Time { return time.Date(2025, 10, 15, hour, 0, 0, 0, time.UTC) }
+	newLogs := func() plog.Logs {
+		logs := plog.NewLogs()
+		records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+		for i, id := range []string{"a", "b", "c"} {
+			record := records.AppendEmpty()
+			record.Body().SetEmptyMap().PutStr("id", id)
+			record.SetTimestamp(pcommon.NewTimestampFromTime(ts(10 + i)))
+		}
+		return logs
+	}
+	ids := func(logs plog.Logs) []string {
+		var ids []string
+		records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		for i := 0; i < records.Len(); i++ {
+			id, _ := records.At(i).Body().Map().Get("id")
+			ids = append(ids, id.Str())
+		}
+		return ids
+	}
+
+	byID := &targetConfig{IDField: "id"}
+	byTimestamp := &targetConfig{Timestamp: &timestampConfig{Field: "ts"}}
+	tests := []struct {
+		name   string
+		target *targetConfig
+		state  targetState
+		want   []string
+	}{
+		{
+			name:   "up to the last id",
+			target: byID,
+			state:  targetState{LastCursor: "c", LastCursorFetched: true, LastID: "b"},
+			want:   []string{"c"},
+		},
+		{
+			name:   "last id not in page",
+			target: byID,
+			state:  targetState{LastCursor: "c", LastCursorFetched: true, LastID: "z"},
+			want:   []string{"a", "b", "c"},
+		},
+		{
+			name:   "not newer than the last timestamp",
+			target: byTimestamp,
+			state:  targetState{LastCursor: "c", LastCursorFetched: true, LastTimestamp: ts(11)},
+			want:   []string{"c"},
+		},
+		{
+			name:   "cursor page not fetched yet",
+			target: byID,
+			state:  targetState{LastCursor: "c", LastID: "b"},
+			want:   []string{"a", "b", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := newLogs()
+			seen := newSeenFilter(tt.target, tt.state, r.extractValueByPath)
+			seen.filter(logs)
+			assert.Equal(t, tt.want, ids(logs))
+
+			// only the first records are filtered
+			logs = newLogs()
+			seen.filter(logs)
+			assert.Equal(t, []string{"a", "b", "c"}, ids(logs))
+		})
+	}
+}
+
+// recordCount returns the number of log records received by the sink.
+func recordCount(sink *testLogsSink) int {
+	count := 0
+	for _, logs := range sink.AllLogs() {
+		count += logs.LogRecordCount()
+	}
+	return count
+}
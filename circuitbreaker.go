@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// errCircuitOpen is the outcome of a poll skipped by an open circuit breaker.
+var errCircuitOpen = errors.New("circuit breaker is open")
+
+// circuitState is the state of a target's circuit breaker.
+type circuitState int64
+
+const (
+	// circuitClosed polls the target on every interval
+	circuitClosed circuitState = iota
+	// circuitOpen skips polls until the open duration has elapsed
+	circuitOpen
+	// circuitHalfOpen lets a single probe poll decide whether to close or reopen
+	circuitHalfOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// circuitBreaker stops polling a target after consecutive failures. While
+// open, polls are skipped; each failed probe doubles the time the breaker
+// stays open, up to the configured maximum. A nil circuitBreaker always
+// allows polls.
+type circuitBreaker struct {
+	cfg *circuitBreakerConfig
+
+	mu        sync.Mutex
+	state     circuitState
+	failures  int
+	openFor   time.Duration
+	openUntil time.Time
+}
+
+// newCircuitBreaker creates a circuit breaker for the config, or nil when it is disabled.
+func newCircuitBreaker(cfg *circuitBreakerConfig) *circuitBreaker {
+	if cfg == nil {
+		return nil
+	}
+
+	return &circuitBreaker{cfg: cfg}
+}
+
+// currentState returns the state of the breaker.
+func (b *circuitBreaker) currentState() circuitState {
+	if b == nil {
+		return circuitClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// allow reports whether the target should be polled now. An open breaker
+// whose open duration has elapsed moves to half-open and allows a probe.
+func (b *circuitBreaker) allow(now time.Time) (allowed bool, from, to circuitState) {
+	if b == nil {
+		return true, circuitClosed, circuitClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	from = b.state
+	if b.state == circuitOpen {
+		if now.Before(b.openUntil) {
+			return false, from, from
+		}
+		b.state = circuitHalfOpen
+	}
+
+	return true, from, b.state
+}
+
+// record updates the breaker with the outcome of a poll and returns the state
+// transition it caused, if any.
+func (b *circuitBreaker) record(pollErr error, now time.Time) (from, to circuitState) {
+	if b == nil {
+		return circuitClosed, circuitClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	from = b.state
+	if pollErr == nil {
+		b.state = circuitClosed
+		b.failures = 0
+		b.openFor = 0
+		return from, b.state
+	}
+
+	b.failures++
+	switch b.state {
+	case circuitClosed:
+		if b.failures >= b.cfg.FailureThreshold {
+			b.open(b.cfg.OpenDuration, now)
+		}
+	case circuitHalfOpen:
+		b.open(min(2*b.openFor, b.cfg.MaxOpenDuration), now)
+	}
+
+	return from, b.state
+}
+
+// open trips the breaker for the given duration.
+func (b *circuitBreaker) open(duration time.Duration, now time.Time) {
+	b.state = circuitOpen
+	b.openFor = duration
+	b.openUntil = now.Add(duration)
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	cfg := &circuitBreakerConfig{FailureThreshold: 2, OpenDuration: time.Minute, MaxOpenDuration: 3 * time.Minute}
+	require.NoError(t, cfg.Validate())
+	b := newCircuitBreaker(cfg)
+	now := time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC)
+	errPoll := errors.New("HTTP error: 503")
+
+	allowed, _, _ := b.allow(now)
+	assert.True(t, allowed)
+	from, to := b.record(errPoll, now)
+	assert.Equal(t, circuitClosed, from)
+	assert.Equal(t, circuitClosed, to)
+
+	// the threshold trips the breaker
+	_, to = b.record(errPoll, now)
+	assert.Equal(t, circuitOpen, to)
+	allowed, _, _ = b.allow(now.Add(59 * time.Second))
+	assert.False(t, allowed)
+
+	// a failed probe reopens it for twice as long
+	allowed, from, to = b.allow(now.Add(time.Minute))
+	assert.True(t, allowed)
+	assert.Equal(t, circuitOpen, from)
+	assert.Equal(t, circuitHalfOpen, to)
+	now = now.Add(time.Minute)
+	_, to = b.record(errPoll, now)
+	assert.Equal(t, circuitOpen, to)
+	allowed, _, _ = b.allow(now.Add(time.Minute + 59*time.Second))
+	assert.False(t, allowed)
+
+	// the open duration is capped
+	now = now.Add(2 * time.Minute)
+	b.allow(now)
+	b.record(errPoll, now)
+	allowed, _, _ = b.allow(now.Add(3 * time.Minute))
+	assert.True(t, allowed)
+	assert.Equal(t, circuitHalfOpen, b.currentState())
+
+	// a successful probe closes it and resets the failure count
+	from, to = b.record(nil, now)
+	assert.Equal(t, circuitHalfOpen, from)
+	assert.Equal(t, circuitClosed, to)
+	_, to = b.record(errPoll, now)
+	assert.Equal(t, circuitClosed, to)
+
+	var disabled *circuitBreaker
+	allowed, _, _ = disabled.allow(now)
+	assert.True(t, allowed)
+	assert.Equal(t, circuitClosed, disabled.currentState())
+}
+
+func TestCircuitBreakerConfig_Validate(t *testing.T) {
+	cfg := &circuitBreakerConfig{}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, 5, cfg.FailureThreshold)
+	assert.Equal(t, time.Minute, cfg.OpenDuration)
+	assert.Equal(t, 15*time.Minute, cfg.MaxOpenDuration)
+
+	assert.Error(t, (&circuitBreakerConfig{FailureThreshold: -1}).Validate())
+	assert.Error(t, (&circuitBreakerConfig{OpenDuration: time.Hour, MaxOpenDuration: time.Minute}).Validate())
+}
+
+func TestLogsReceiver_CircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{
+		ClientConfig:       confighttp.ClientConfig{Endpoint: srv.URL},
+		CollectionInterval: 5 * time.Millisecond,
+		CircuitBreaker:     &circuitBreakerConfig{FailureThreshold: 2, OpenDuration: 150 * time.Millisecond},
+	}
+	require.NoError(t, target.Validate())
+
+	tel := componenttest.NewTelemetry()
+	defer func() { require.NoError(t, tel.Shutdown(context.Background())) }()
+	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
+	settings.TelemetrySettings = tel.NewTelemetrySettings()
+
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{CollectionInterval: time.Hour, Targets: []*targetConfig{target}}, settings, sink)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	require.Eventually(t, func() bool { return r.breakers[target].currentState() == circuitOpen }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, int64(circuitOpen), circuitStateValue(t, tel, srv.URL))
+
+	// no polls are made while the breaker is open
+	opened := calls.Load()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, opened, calls.Load())
+
+	healthy.Store(true)
+	waitForLogs(t, sink, 1, 2*time.Second)
+	assert.Equal(t, circuitClosed, r.breakers[target].currentState())
+	assert.Equal(t, int64(circuitClosed), circuitStateValue(t, tel, srv.URL))
+}
+
+// circuitStateValue returns the reported circuit breaker state of the target.
+func circuitStateValue(t *testing.T, tel *componenttest.Telemetry, endpoint string) int64 {
+	m, err := tel.GetMetric("otelcol_logsreceiver_target_circuit_breaker_state")
+	require.NoError(t, err)
+	gauge, ok := m.Data.(metricdata.Gauge[int64])
+	require.True(t, ok)
+	for _, dp := range gauge.DataPoints {
+		if v, _ := dp.Attributes.Value(targetAttribute); v.AsString() == endpoint {
+			return dp.Value
+		}
+	}
+	t.Fatalf("no circuit breaker state reported for %s", endpoint)
+	return 0
+}
@@ -3,14 +3,69 @@ package logsreceiver
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
 	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap"
 )
 
 // Predefined error responses for configuration validation failures
 var (
 	errInvalidEndpoint = errors.New(`"endpoint" must be in the form of <scheme>://<hostname>[:<port>]`)
 	errMissingEndpoint = errors.New("endpoint must be specified")
+	errMissingTSField  = errors.New(`"timestamp.field" must be specified`)
+	errMissingSevField = errors.New(`"severity.field" must be specified`)
+	errAuthHeader      = errors.New(`a static "Authorization" header cannot be combined with "auth"`)
+)
+
+// Supported pagination modes
+const (
+	paginationModeNextURL    = "next_url"
+	paginationModeLinkHeader = "link_header"
+	paginationModeCursor     = "cursor"
+	paginationModeOffset     = "offset"
+	paginationModePage       = "page"
+)
+
+// Supported response formats
+const (
+	formatAuto   = "auto"
+	formatJSON   = "json"
+	formatNDJSON = "ndjson"
+	formatText   = "text"
+	formatCSV    = "csv"
+	formatTSV    = "tsv"
+	formatXML    = "xml"
+	formatSyslog = "syslog"
+	formatLogfmt = "logfmt"
+
+	formatOTLPJSON  = "otlp_json"
+	formatOTLPProto = "otlp_proto"
+)
+
+// supportedFormats lists the valid values of a target's format
+var supportedFormats = []string{formatAuto, formatJSON, formatNDJSON, formatText, formatCSV, formatTSV, formatXML, formatSyslog, formatLogfmt, formatOTLPJSON, formatOTLPProto}
+
+// Supported CSV column types
+const (
+	columnTypeString = "string"
+	columnTypeInt    = "int"
+	columnTypeFloat  = "float"
+	columnTypeBool   = "bool"
+)
+
+// Supported timestamp layout types
+const (
+	layoutTypeGoTime   = "gotime"
+	layoutTypeStrptime = "strptime"
+	layoutTypeEpoch    = "epoch"
 )
 
 type Config struct {
@@ -18,17 +73,29 @@ type Config struct {
 
 	Targets []*targetConfig `mapstructure:"targets"`
 
+	// Storage extension used to persist per-target state across restarts
+	StorageID *component.ID `mapstructure:"storage"`
+
+	// Consecutive polls of a target rejected with 401 or 403 after which the
+	// receiver reports a permanent error. 0 keeps them recoverable
+	AuthFailureThreshold int `mapstructure:"auth_failure_threshold"`
+
 	_ struct{}
 }
 
 type targetConfig struct {
-	Endpoint string `mapstructure:"endpoint"`
+	// HTTP client settings: endpoint, headers, TLS, proxy, timeout, compression and auth
+	confighttp.ClientConfig `mapstructure:",squash"`
 
 	Method string `mapstructure:"method"`
 
 	Body string `mapstructure:"body"`
 
-	Headers map[string]string `mapstructure:"headers"`
+	// How often to poll this target. Default: the receiver's collection_interval
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+
+	// Delay before the first poll of this target
+	InitialDelay time.Duration `mapstructure:"initial_delay"`
 
 	// Service name to assign to logs
 	ServiceName string `mapstructure:"service_name"`
@@ -38,6 +105,515 @@ type targetConfig struct {
 
 	// Additional attributes to add to each log record
 	Labels map[string]string `mapstructure:"labels"`
+
+	// Format of the responses, or auto to detect it from the Content-Type and body
+	Format string `mapstructure:"format"`
+
+	// Dot-separated path to the array of records inside a JSON envelope
+	RecordsPath string `mapstructure:"records_path"`
+
+	// Envelope fields copied onto every record, keyed by attribute name
+	EnvelopeAttributes map[string]string `mapstructure:"envelope_attributes"`
+
+	// Timestamp extraction from a record field
+	Timestamp *timestampConfig `mapstructure:"timestamp"`
+
+	// Severity extraction from a record field
+	Severity *severityConfig `mapstructure:"severity"`
+
+	// Fetching of further result pages within one poll
+	Pagination *paginationConfig `mapstructure:"pagination"`
+
+	// Dot-separated path to the record ID stored as the last seen ID
+	IDField string `mapstructure:"id_field"`
+
+	// Retries of failed requests within one poll
+	Retry *retryConfig `mapstructure:"retry"`
+
+	// Backing off from a target after consecutive failed polls
+	CircuitBreaker *circuitBreakerConfig `mapstructure:"circuit_breaker"`
+
+	// Incremental decoding of large JSON responses
+	Streaming *streamingConfig `mapstructure:"streaming"`
+
+	// Parsing of text lines with a regular expression
+	Regex *regexConfig `mapstructure:"regex"`
+
+	// Grouping of text lines into multiline records
+	Multiline *multilineConfig `mapstructure:"multiline"`
+
+	// Parsing of CSV and TSV responses
+	CSV *csvConfig `mapstructure:"csv"`
+
+	// Parsing of XML responses
+	XML *xmlConfig `mapstructure:"xml"`
+
+	// Parsing of syslog responses
+	Syslog *syslogConfig `mapstructure:"syslog"`
+
+	// Parsed endpoint, body and header templates, nil for text without template actions
+	endpointTemplate *template.Template
+	bodyTemplate     *template.Template
+	headerTemplates  map[string]*template.Template
+}
+
+type syslogConfig struct {
+	// Time zone of RFC 3164 timestamps, which carry none. Default: UTC
+	Location string `mapstructure:"location"`
+
+	location *time.Location
+}
+
+func (cfg *syslogConfig) Validate() error {
+	if cfg.Location == "" {
+		cfg.Location = "UTC"
+	}
+
+	loc, err := time.LoadLocation(cfg.Location)
+	if err != nil {
+		return fmt.Errorf(`invalid "syslog.location" %q: %w`, cfg.Location, err)
+	}
+	cfg.location = loc
+
+	return nil
+}
+
+type xmlConfig struct {
+	// Slash-separated path of the repeating element that becomes a record,
+	// e.g. /Envelope/Body/Events/Event. Default: the children of the root element
+	RecordPath string `mapstructure:"record_path"`
+
+	recordPath []string
+}
+
+func (cfg *xmlConfig) Validate() error {
+	cfg.recordPath = nil
+	if path := strings.Trim(cfg.RecordPath, "/"); path != "" {
+		cfg.recordPath = strings.Split(path, "/")
+	}
+
+	if slices.Contains(cfg.recordPath, "") {
+		return fmt.Errorf(`invalid "xml.record_path" %q: must not contain empty elements`, cfg.RecordPath)
+	}
+
+	return nil
+}
+
+type csvConfig struct {
+	// Field delimiter. Default: a comma for csv and a tab for tsv
+	Delimiter string `mapstructure:"delimiter"`
+
+	// Column names. When set, the first row is data instead of a header row
+	Header []string `mapstructure:"header"`
+
+	// Types the values of columns are converted to: string, int, float or bool
+	Types map[string]string `mapstructure:"types"`
+
+	delimiter rune
+}
+
+func (cfg *csvConfig) Validate() error {
+	if cfg.Delimiter != "" {
+		delimiter := []rune(cfg.Delimiter)
+		if len(delimiter) != 1 || delimiter[0] == '"' || delimiter[0] == '\r' || delimiter[0] == '\n' {
+			return fmt.Errorf(`invalid "csv.delimiter" %q: must be a single character other than a quote or line break`, cfg.Delimiter)
+		}
+		cfg.delimiter = delimiter[0]
+	}
+
+	for column, columnType := range cfg.Types {
+		switch columnType {
+		case columnTypeString, columnTypeInt, columnTypeFloat, columnTypeBool:
+		default:
+			return fmt.Errorf(`invalid "csv.types" %q for column %q: must be one of string, int, float, bool`, columnType, column)
+		}
+	}
+
+	return nil
+}
+
+type multilineConfig struct {
+	// Regular expression matching the first line of each record
+	LineStartPattern string `mapstructure:"line_start_pattern"`
+
+	// Regular expression matching the last line of each record
+	LineEndPattern string `mapstructure:"line_end_pattern"`
+
+	// Lines per record, after which the record is cut. Default: 1000
+	MaxLines int `mapstructure:"max_lines"`
+
+	// Bytes per record, after which the record is cut. Default: 1048576
+	MaxBytes int `mapstructure:"max_bytes"`
+
+	// Keep the indentation of lines instead of trimming it
+	PreserveLeadingWhitespace bool `mapstructure:"preserve_leading_whitespace"`
+
+	lineStart *regexp.Regexp
+	lineEnd   *regexp.Regexp
+}
+
+func (cfg *multilineConfig) Validate() error {
+	if (cfg.LineStartPattern == "") == (cfg.LineEndPattern == "") {
+		return errors.New(`exactly one of "multiline.line_start_pattern" and "multiline.line_end_pattern" must be specified`)
+	}
+
+	var err error
+	if cfg.LineStartPattern != "" {
+		if cfg.lineStart, err = regexp.Compile(cfg.LineStartPattern); err != nil {
+			return fmt.Errorf(`invalid "multiline.line_start_pattern": %w`, err)
+		}
+	}
+	if cfg.LineEndPattern != "" {
+		if cfg.lineEnd, err = regexp.Compile(cfg.LineEndPattern); err != nil {
+			return fmt.Errorf(`invalid "multiline.line_end_pattern": %w`, err)
+		}
+	}
+
+	if cfg.MaxLines < 0 {
+		return errors.New(`"multiline.max_lines" must not be negative`)
+	}
+	if cfg.MaxLines == 0 {
+		cfg.MaxLines = 1000
+	}
+
+	if cfg.MaxBytes < 0 {
+		return errors.New(`"multiline.max_bytes" must not be negative`)
+	}
+	if cfg.MaxBytes == 0 {
+		cfg.MaxBytes = 1 << 20
+	}
+
+	return nil
+}
+
+type regexConfig struct {
+	// Regular expression matched against each text line. Named groups become
+	// record attributes, except timestamp, severity and body.
+	Pattern string `mapstructure:"pattern"`
+
+	regexp *regexp.Regexp
+
+	// Extraction of the timestamp and severity from the captured groups
+	timestamp *timestampConfig
+	severity  *severityConfig
+}
+
+func (cfg *regexConfig) Validate() error {
+	if cfg.Pattern == "" {
+		return errors.New(`"regex.pattern" must be specified`)
+	}
+
+	re, err := regexp.Compile(cfg.Pattern)
+	if err != nil {
+		return fmt.Errorf(`invalid "regex.pattern": %w`, err)
+	}
+	cfg.regexp = re
+
+	return nil
+}
+
+type streamingConfig struct {
+	// Log records passed to the pipeline per batch. Default: 1000
+	BatchSize int `mapstructure:"batch_size"`
+}
+
+func (cfg *streamingConfig) Validate() error {
+	if cfg.BatchSize < 0 {
+		return errors.New(`"streaming.batch_size" must not be negative`)
+	}
+
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = 1000
+	}
+
+	return nil
+}
+
+type circuitBreakerConfig struct {
+	// Consecutive failed polls that open the breaker. Default: 5
+	FailureThreshold int `mapstructure:"failure_threshold"`
+
+	// Time the breaker stays open before a probe poll. Default: 1m
+	OpenDuration time.Duration `mapstructure:"open_duration"`
+
+	// Upper bound of the open duration, which doubles after each failed probe. Default: 15m
+	MaxOpenDuration time.Duration `mapstructure:"max_open_duration"`
+}
+
+func (cfg *circuitBreakerConfig) Validate() error {
+	if cfg.FailureThreshold < 0 {
+		return errors.New(`"circuit_breaker.failure_threshold" must not be negative`)
+	}
+
+	if cfg.FailureThreshold == 0 {
+		cfg.FailureThreshold = 5
+	}
+
+	if cfg.OpenDuration == 0 {
+		cfg.OpenDuration = time.Minute
+	}
+
+	if cfg.MaxOpenDuration == 0 {
+		cfg.MaxOpenDuration = max(15*time.Minute, cfg.OpenDuration)
+	}
+
+	if cfg.OpenDuration < 0 || cfg.MaxOpenDuration < cfg.OpenDuration {
+		return errors.New(`"circuit_breaker.open_duration" must be positive and not exceed "circuit_breaker.max_open_duration"`)
+	}
+
+	return nil
+}
+
+type retryConfig struct {
+	// Maximum number of attempts per request, including the first. Default: 3
+	MaxAttempts int `mapstructure:"max_attempts"`
+
+	// Delay before the first retry, doubled for each further retry. Default: 1s
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+
+	// Upper bound of the delay between retries. Default: 30s
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+
+	// Fraction of the delay randomly added or subtracted, between 0 and 1. Default: 0.2
+	Jitter *float64 `mapstructure:"jitter"`
+
+	// Response status codes that are retried. Default: 429, 502, 503, 504
+	StatusCodes []int `mapstructure:"status_codes"`
+}
+
+func (cfg *retryConfig) Validate() error {
+	if cfg.MaxAttempts < 0 {
+		return errors.New(`"retry.max_attempts" must not be negative`)
+	}
+
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = 3
+	}
+
+	if cfg.InitialBackoff == 0 {
+		cfg.InitialBackoff = time.Second
+	}
+
+	if cfg.MaxBackoff == 0 {
+		cfg.MaxBackoff = 30 * time.Second
+	}
+
+	if cfg.InitialBackoff < 0 || cfg.MaxBackoff < cfg.InitialBackoff {
+		return errors.New(`"retry.initial_backoff" must be positive and not exceed "retry.max_backoff"`)
+	}
+
+	if cfg.Jitter == nil {
+		jitter := 0.2
+		cfg.Jitter = &jitter
+	}
+
+	if *cfg.Jitter < 0 || *cfg.Jitter > 1 {
+		return fmt.Errorf(`invalid "retry.jitter" %v: must be between 0 and 1`, *cfg.Jitter)
+	}
+
+	if len(cfg.StatusCodes) == 0 {
+		cfg.StatusCodes = []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+	}
+
+	for _, code := range cfg.StatusCodes {
+		if code < 400 || code > 599 {
+			return fmt.Errorf(`invalid "retry.status_codes" entry %d: must be a 4xx or 5xx status`, code)
+		}
+	}
+
+	return nil
+}
+
+type paginationConfig struct {
+	// One of next_url, link_header, cursor, offset or page
+	Mode string `mapstructure:"mode"`
+
+	// Dot-separated path to the next page URL in the response (next_url mode)
+	NextURLPath string `mapstructure:"next_url_path"`
+
+	// Dot-separated path to the next cursor in the response (cursor mode)
+	CursorPath string `mapstructure:"cursor_path"`
+
+	// Query parameter carrying the cursor (cursor mode)
+	CursorParam string `mapstructure:"cursor_param"`
+
+	// Query parameter carrying the offset. Default: offset
+	OffsetParam string `mapstructure:"offset_param"`
+
+	// Query parameter carrying the page number. Default: page
+	PageParam string `mapstructure:"page_param"`
+
+	// First page number. Default: 1
+	StartPage int `mapstructure:"start_page"`
+
+	// Query parameter carrying the page size. Default: limit
+	LimitParam string `mapstructure:"limit_param"`
+
+	// Page size sent with offset and page requests; a shorter page ends pagination
+	Limit int `mapstructure:"limit"`
+
+	// Dot-separated path to a boolean that must be true for another page to be fetched
+	HasMorePath string `mapstructure:"has_more_path"`
+
+	// Maximum number of pages fetched per poll. Default: 100
+	MaxPages int `mapstructure:"max_pages"`
+}
+
+func (cfg *paginationConfig) Validate() error {
+	switch cfg.Mode {
+	case paginationModeNextURL:
+		if cfg.NextURLPath == "" {
+			return errors.New(`"pagination.next_url_path" must be specified for next_url mode`)
+		}
+	case paginationModeLinkHeader:
+	case paginationModeCursor:
+		if cfg.CursorPath == "" || cfg.CursorParam == "" {
+			return errors.New(`"pagination.cursor_path" and "pagination.cursor_param" must be specified for cursor mode`)
+		}
+	case paginationModeOffset:
+		if cfg.OffsetParam == "" {
+			cfg.OffsetParam = "offset"
+		}
+	case paginationModePage:
+		if cfg.PageParam == "" {
+			cfg.PageParam = "page"
+		}
+		if cfg.StartPage == 0 {
+			cfg.StartPage = 1
+		}
+	default:
+		return fmt.Errorf(`invalid "pagination.mode" %q: must be one of next_url, link_header, cursor, offset, page`, cfg.Mode)
+	}
+
+	if cfg.Limit < 0 {
+		return errors.New(`"pagination.limit" must not be negative`)
+	}
+
+	if cfg.LimitParam == "" {
+		cfg.LimitParam = "limit"
+	}
+
+	if cfg.MaxPages < 0 {
+		return errors.New(`"pagination.max_pages" must not be negative`)
+	}
+
+	if cfg.MaxPages == 0 {
+		cfg.MaxPages = 100
+	}
+
+	return nil
+}
+
+type timestampConfig struct {
+	// Dot-separated path to the timestamp field of each record
+	Field string `mapstructure:"field"`
+
+	// One of gotime, strptime or epoch. Default: gotime
+	LayoutType string `mapstructure:"layout_type"`
+
+	// Layout of the field. For epoch one of s, ms, us or ns. Default: RFC3339 for gotime, s for epoch
+	Layout string `mapstructure:"layout"`
+
+	// Time zone used when the layout carries no offset. Default: UTC
+	Location string `mapstructure:"location"`
+
+	location *time.Location
+	// Go layout converted from a strptime layout
+	goLayout string
+}
+
+type severityConfig struct {
+	// Dot-separated path to the severity field of each record
+	Field string `mapstructure:"field"`
+
+	// Severity levels (e.g. error, warn2) mapped to the source values they match.
+	// Values are strings, numbers or {min, max} numeric ranges.
+	Mapping map[string][]interface{} `mapstructure:"mapping"`
+
+	mapper *severityMapper
+}
+
+func (cfg *severityConfig) Validate() error {
+	if cfg.Field == "" {
+		return errMissingSevField
+	}
+
+	mapper, err := newSeverityMapper(cfg.Mapping)
+	if err != nil {
+		return fmt.Errorf(`invalid "severity.mapping": %w`, err)
+	}
+	cfg.mapper = mapper
+
+	return nil
+}
+
+func (cfg *timestampConfig) Validate() error {
+	if cfg.Field == "" {
+		return errMissingTSField
+	}
+
+	if cfg.LayoutType == "" {
+		cfg.LayoutType = layoutTypeGoTime
+	}
+
+	switch cfg.LayoutType {
+	case layoutTypeGoTime:
+		if cfg.Layout == "" {
+			cfg.Layout = time.RFC3339Nano
+		}
+	case layoutTypeStrptime:
+		if cfg.Layout == "" {
+			return errors.New(`"timestamp.layout" must be specified for strptime`)
+		}
+		layout, err := strptimeToGoLayout(cfg.Layout)
+		if err != nil {
+			return fmt.Errorf(`invalid strptime "timestamp.layout" %q: %w`, cfg.Layout, err)
+		}
+		cfg.goLayout = layout
+	case layoutTypeEpoch:
+		if cfg.Layout == "" {
+			cfg.Layout = "s"
+		}
+		switch cfg.Layout {
+		case "s", "ms", "us", "ns":
+		default:
+			return fmt.Errorf(`invalid epoch "timestamp.layout" %q: must be one of s, ms, us, ns`, cfg.Layout)
+		}
+	default:
+		return fmt.Errorf(`invalid "timestamp.layout_type" %q: must be one of gotime, strptime, epoch`, cfg.LayoutType)
+	}
+
+	if cfg.Location == "" {
+		cfg.Location = "UTC"
+	}
+
+	loc, err := time.LoadLocation(cfg.Location)
+	if err != nil {
+		return fmt.Errorf(`invalid "timestamp.location": %w`, err)
+	}
+	cfg.location = loc
+
+	return nil
+}
+
+// newDefaultTargetConfig returns a target with the collector's default HTTP
+// client settings and a request timeout.
+func newDefaultTargetConfig() targetConfig {
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Timeout = 30 * time.Second
+	return targetConfig{ClientConfig: clientConfig}
+}
+
+// Unmarshal decodes a target on top of the default target settings, which
+// the elements of the targets list do not get from createDefaultConfig.
+func (cfg *targetConfig) Unmarshal(conf *confmap.Conf) error {
+	type plain targetConfig
+	defaults := plain(newDefaultTargetConfig())
+	if err := conf.Unmarshal(&defaults); err != nil {
+		return err
+	}
+	*cfg = targetConfig(defaults)
+	return nil
 }
 
 func (cfg *targetConfig) Validate() error {
@@ -45,10 +621,59 @@ func (cfg *targetConfig) Validate() error {
 		return errMissingEndpoint
 	}
 
-	if _, parseErr := url.ParseRequestURI(cfg.Endpoint); parseErr != nil {
+	// Templates are parsed once and checked by rendering them with the variables of a first poll
+	vars := newTemplateVars(time.Now(), targetState{}, 0)
+
+	var err error
+	if cfg.endpointTemplate, err = parseTemplate("endpoint", cfg.Endpoint); err != nil {
+		return err
+	}
+	endpoint, err := renderTemplate(cfg.endpointTemplate, cfg.Endpoint, vars)
+	if err != nil {
+		return err
+	}
+
+	if _, parseErr := url.ParseRequestURI(endpoint); parseErr != nil {
 		return fmt.Errorf("%s: %w", errInvalidEndpoint.Error(), parseErr)
 	}
 
+	if cfg.bodyTemplate, err = parseTemplate("body", cfg.Body); err != nil {
+		return err
+	}
+	if _, err := renderTemplate(cfg.bodyTemplate, cfg.Body, vars); err != nil {
+		return err
+	}
+
+	cfg.headerTemplates = map[string]*template.Template{}
+	for key, value := range cfg.Headers {
+		tmpl, err := parseTemplate("header "+key, string(value))
+		if err != nil {
+			return err
+		}
+		if _, err := renderTemplate(tmpl, string(value), vars); err != nil {
+			return err
+		}
+		if tmpl != nil {
+			cfg.headerTemplates[key] = tmpl
+		}
+		// The authenticator owns the Authorization header and would silently replace it
+		if cfg.Auth.HasValue() && strings.EqualFold(key, "Authorization") {
+			return errAuthHeader
+		}
+	}
+
+	if err := cfg.ClientConfig.Validate(); err != nil {
+		return err
+	}
+
+	if cfg.CollectionInterval < 0 {
+		return errors.New(`"collection_interval" must not be negative`)
+	}
+
+	if cfg.InitialDelay < 0 {
+		return errors.New(`"initial_delay" must not be negative`)
+	}
+
 	if cfg.Method == "" {
 		cfg.Method = "GET"
 	}
@@ -61,6 +686,101 @@ func (cfg *targetConfig) Validate() error {
 		cfg.LogLevel = "info"
 	}
 
+	if cfg.Format == "" {
+		cfg.Format = formatAuto
+	}
+	if !slices.Contains(supportedFormats, cfg.Format) {
+		return fmt.Errorf(`"format" must be one of %s`, strings.Join(supportedFormats, ", "))
+	}
+
+	if cfg.Timestamp != nil {
+		if err := cfg.Timestamp.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Severity != nil {
+		if err := cfg.Severity.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Pagination != nil {
+		if err := cfg.Pagination.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Retry != nil {
+		if err := cfg.Retry.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.CircuitBreaker != nil {
+		if err := cfg.CircuitBreaker.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Streaming != nil {
+		if err := cfg.Streaming.Validate(); err != nil {
+			return err
+		}
+
+		// Records are emitted as they are decoded, so envelope values cannot come from within them
+		records := splitPath(cfg.RecordsPath)
+		for key, path := range cfg.EnvelopeAttributes {
+			if envelope := splitPath(path); isPrefix(envelope, records) || isPrefix(records, envelope) {
+				return fmt.Errorf(`"envelope_attributes.%s" %q must lie outside "records_path" %q when streaming`, key, path, cfg.RecordsPath)
+			}
+		}
+	}
+
+	if cfg.CSV != nil {
+		if err := cfg.CSV.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.XML != nil {
+		if err := cfg.XML.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Syslog != nil {
+		if err := cfg.Syslog.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Multiline != nil {
+		if err := cfg.Multiline.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Regex != nil {
+		if err := cfg.Regex.Validate(); err != nil {
+			return err
+		}
+
+		// The target's timestamp and severity configs address the captured
+		// groups; without them the timestamp group is parsed as RFC 3339 and
+		// the severity group is matched against the standard level names.
+		cfg.Regex.timestamp, cfg.Regex.severity = cfg.Timestamp, cfg.Severity
+		if cfg.Regex.timestamp == nil {
+			cfg.Regex.timestamp = &timestampConfig{Field: regexGroupTimestamp}
+			if err := cfg.Regex.timestamp.Validate(); err != nil {
+				return err
+			}
+		}
+		if cfg.Regex.severity == nil {
+			cfg.Regex.severity = &severityConfig{Field: regexGroupSeverity}
+		}
+	}
+
 	return nil
 }
 
@@ -73,10 +793,17 @@ func (cfg *Config) Validate() error {
 		cfg.CollectionInterval = 30 * time.Second
 	}
 
+	if cfg.AuthFailureThreshold < 0 {
+		return errors.New(`"auth_failure_threshold" must not be negative`)
+	}
+
 	for _, target := range cfg.Targets {
 		if err := target.Validate(); err != nil {
 			return err
 		}
+		if target.CollectionInterval == 0 {
+			target.CollectionInterval = cfg.CollectionInterval
+		}
 	}
 
 	return nil
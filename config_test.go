@@ -9,6 +9,13 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -23,8 +30,8 @@ func TestConfig_Validate(t *testing.T) {
 				CollectionInterval: 10 * time.Second,
 				Targets: []*targetConfig{
 					{
-						Endpoint: "http://example.com/logs",
-						Method:   "GET",
+						ClientConfig: confighttp.ClientConfig{Endpoint: "http://example.com/logs"},
+						Method:       "GET",
 					},
 				},
 			},
@@ -44,13 +51,24 @@ func TestConfig_Validate(t *testing.T) {
 				CollectionInterval: 10 * time.Second,
 				Targets: []*targetConfig{
 					{
-						Endpoint: "invalid-url",
-						Method:   "GET",
+						ClientConfig: confighttp.ClientConfig{Endpoint: "invalid-url"},
+						Method:       "GET",
 					},
 				},
 			},
 			wantErr: true,
 		},
+		{
+			name: "negative auth failure threshold",
+			config: Config{
+				CollectionInterval: 10 * time.Second,
+				Targets: []*targetConfig{
+					{ClientConfig: confighttp.ClientConfig{Endpoint: "http://example.com/logs"}},
+				},
+				AuthFailureThreshold: -1,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -74,21 +92,335 @@ func TestTargetConfig_Validate(t *testing.T) {
 		{
 			name: "valid config with defaults",
 			config: targetConfig{
-				Endpoint: "https://api.example.com/logs",
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
 			},
 			wantErr: false,
 		},
 		{
 			name: "valid config with custom values",
 			config: targetConfig{
-				Endpoint:    "https://api.example.com/logs",
-				Method:      "POST",
-				ServiceName: "my-service",
-				LogLevel:    "debug",
-				Labels:      map[string]string{"env": "test", "region": "us"},
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Method:       "POST",
+				ServiceName:  "my-service",
+				LogLevel:     "debug",
+				Labels:       map[string]string{"env": "test", "region": "us"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid timestamp config",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Timestamp:    &timestampConfig{Field: "ts", LayoutType: "epoch", Layout: "ms", Location: "Europe/Berlin"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "timestamp without field",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Timestamp:    &timestampConfig{LayoutType: "gotime"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "timestamp invalid layout type",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Timestamp:    &timestampConfig{Field: "ts", LayoutType: "unix"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "timestamp invalid epoch unit",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Timestamp:    &timestampConfig{Field: "ts", LayoutType: "epoch", Layout: "minutes"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported strptime directive",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Timestamp:    &timestampConfig{Field: "ts", LayoutType: "strptime", Layout: "%Y-%m-%d %Q"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "timestamp invalid location",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Timestamp:    &timestampConfig{Field: "ts", Location: "Mars/Olympus"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid severity config",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Severity:     &severityConfig{Field: "level", Mapping: map[string][]interface{}{"error": {"E", 50}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "severity without field",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Severity:     &severityConfig{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "severity unknown level",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Severity:     &severityConfig{Field: "level", Mapping: map[string][]interface{}{"critical": {"C"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid pagination config",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Pagination:   &paginationConfig{Mode: "cursor", CursorPath: "meta.next", CursorParam: "cursor"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "pagination invalid mode",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Pagination:   &paginationConfig{Mode: "scroll"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "pagination next_url without path",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Pagination:   &paginationConfig{Mode: "next_url"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid templated endpoint",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs?since={{ unix .WindowStart }}"},
+				Body:         `{"from":"{{ .WindowStart | format "2006-01-02" }}"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid body template",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Body:         `{"from":"{{ .WindowStart "}`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown header template variable",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{
+					Endpoint: "https://api.example.com/logs",
+					Headers:  map[string]configopaque.String{"X-Cursor": "{{ .NextCursor }}"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid auth extension",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{
+					Endpoint: "https://api.example.com/logs",
+					Auth:     configoptional.Some(configauth.Config{AuthenticatorID: component.MustNewID("oauth2client")}),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "auth extension with static authorization header",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{
+					Endpoint: "https://api.example.com/logs",
+					Headers:  map[string]configopaque.String{"authorization": "Bearer static"},
+					Auth:     configoptional.Some(configauth.Config{AuthenticatorID: component.MustNewID("oauth2client")}),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid schedule",
+			config: targetConfig{
+				ClientConfig:       confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				CollectionInterval: time.Minute,
+				InitialDelay:       5 * time.Second,
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative collection interval",
+			config: targetConfig{
+				ClientConfig:       confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				CollectionInterval: -time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative initial delay",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				InitialDelay: -time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid streaming config",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Streaming:    &streamingConfig{BatchSize: 500},
 			},
 			wantErr: false,
 		},
+		{
+			name: "negative streaming batch size",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Streaming:    &streamingConfig{BatchSize: -1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid format",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Format:       "ndjson",
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown format",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Format:       "yaml",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid regex config",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Regex:        &regexConfig{Pattern: `^(?P<severity>\w+) (?P<body>.*)$`},
+			},
+			wantErr: false,
+		},
+		{
+			name: "regex without pattern",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Regex:        &regexConfig{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid regex pattern",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Regex:        &regexConfig{Pattern: `(?P<body>.*`},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid multiline config",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Multiline:    &multilineConfig{LineStartPattern: `^\d{4}-`},
+			},
+			wantErr: false,
+		},
+		{
+			name: "multiline with both patterns",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Multiline:    &multilineConfig{LineStartPattern: `^\d{4}-`, LineEndPattern: `;$`},
+			},
+			wantErr: true,
+		},
+		{
+			name: "multiline negative max lines",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Multiline:    &multilineConfig{LineEndPattern: `;$`, MaxLines: -1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid csv config",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Format:       "csv",
+				CSV:          &csvConfig{Delimiter: ";", Types: map[string]string{"count": "int"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "csv invalid delimiter",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				CSV:          &csvConfig{Delimiter: "||"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "csv unknown column type",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				CSV:          &csvConfig{Types: map[string]string{"count": "decimal"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid xml config",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				XML:          &xmlConfig{RecordPath: "/Envelope/Body/Events/Event"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "xml record path with empty element",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				XML:          &xmlConfig{RecordPath: "/Envelope//Event"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid syslog config",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Format:       "syslog",
+				Syslog:       &syslogConfig{Location: "Europe/Berlin"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid otlp format",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Format:       "otlp_proto",
+			},
+			wantErr: false,
+		},
+		{
+			name: "syslog invalid location",
+			config: targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://api.example.com/logs"},
+				Syslog:       &syslogConfig{Location: "Mars/Olympus"},
+			},
+			wantErr: true,
+		},
 		{
 			name: "missing endpoint",
 			config: targetConfig{
@@ -99,7 +431,7 @@ func TestTargetConfig_Validate(t *testing.T) {
 		{
 			name: "invalid endpoint",
 			config: targetConfig{
-				Endpoint: "not-a-url",
+				ClientConfig: confighttp.ClientConfig{Endpoint: "not-a-url"},
 			},
 			wantErr: true,
 		},
@@ -122,7 +454,58 @@ func TestTargetConfig_Validate(t *testing.T) {
 				if tt.config.LogLevel == "" {
 					assert.Equal(t, "info", tt.config.LogLevel)
 				}
+				assert.NotEmpty(t, tt.config.Format)
 			}
 		})
 	}
 }
+
+func TestConfig_Unmarshal(t *testing.T) {
+	cm := confmap.NewFromStringMap(map[string]any{
+		"collection_interval": "15s",
+		"targets": []any{
+			map[string]any{
+				"endpoint": "https://api.example.com/logs",
+				"method":   "POST",
+				"headers":  map[string]any{"X-Api-Key": "secret"},
+				"timeout":  "5s",
+				"tls":      map[string]any{"insecure_skip_verify": true},
+				"auth":     map[string]any{"authenticator": "basicauth/client"},
+			},
+			map[string]any{
+				"endpoint":            "https://api.example.com/slow",
+				"collection_interval": "5m",
+				"initial_delay":       "10s",
+			},
+		},
+	})
+
+	cfg := createDefaultConfig().(*Config)
+	require.NoError(t, cm.Unmarshal(cfg))
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	require.Len(t, cfg.Targets, 2)
+	target := cfg.Targets[0]
+	assert.Equal(t, 15*time.Second, cfg.CollectionInterval)
+	assert.Equal(t, 3, cfg.AuthFailureThreshold)
+	assert.Equal(t, "https://api.example.com/logs", target.Endpoint)
+	assert.Equal(t, "POST", target.Method)
+	assert.Equal(t, configopaque.String("secret"), target.Headers["X-Api-Key"])
+	assert.Equal(t, 5*time.Second, target.Timeout)
+	assert.True(t, target.TLS.InsecureSkipVerify)
+	require.True(t, target.Auth.HasValue())
+	assert.Equal(t, component.MustNewIDWithName("basicauth", "client"), target.Auth.Get().AuthenticatorID)
+	// targets without an override inherit the receiver's interval
+	assert.Equal(t, 15*time.Second, target.CollectionInterval)
+	assert.Equal(t, 5*time.Minute, cfg.Targets[1].CollectionInterval)
+	assert.Equal(t, 10*time.Second, cfg.Targets[1].InitialDelay)
+
+	// targets start from the collector's default client settings
+	defaults := confighttp.NewDefaultClientConfig()
+	assert.Equal(t, 30*time.Second, cfg.Targets[1].Timeout)
+	for _, target := range cfg.Targets {
+		assert.Equal(t, defaults.MaxIdleConns, target.MaxIdleConns)
+		assert.Equal(t, defaults.IdleConnTimeout, target.IdleConnTimeout)
+		assert.True(t, target.ForceAttemptHTTP2)
+	}
+}
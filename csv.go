@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+// parseCSVLogs parses CSV or TSV, where every row becomes a log record with a
+// map body keyed by the column names. The names come from the configured
+// header or else the first row. Rows with a different number of fields than
+// there are columns are skipped and counted.
+func (r *logsReceiver) parseCSVLogs(ctx context.Context, body []byte, format string, target *targetConfig, pollTime time.Time, logs plog.Logs, marks *recordMarks) (plog.Logs, error) {
+	cfg := target.CSV
+	if cfg == nil {
+		cfg = &csvConfig{}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(body, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	switch {
+	case cfg.delimiter != 0:
+		reader.Comma = cfg.delimiter
+	case format == formatTSV:
+		reader.Comma = '\t'
+	}
+
+	header := cfg.Header
+	if len(header) == 0 {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return logs, nil
+		}
+		if err != nil {
+			return plog.Logs{}, fmt.Errorf("failed to read %s header: %w", format, err)
+		}
+		header = make([]string, len(row))
+		for i, name := range row {
+			header[i] = strings.TrimSpace(name)
+		}
+	}
+
+	scopeLogs := appendTargetScopeLogs(logs, target)
+
+	skipped := 0
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return plog.Logs{}, fmt.Errorf("failed to read %s: %w", format, err)
+		}
+
+		if len(row) != len(header) {
+			skipped++
+			continue
+		}
+
+		record := make(map[string]interface{}, len(header))
+		for i, name := range header {
+			record[name] = r.csvValue(row[i], cfg.Types[name], name, target)
+		}
+		r.addLogRecord(scopeLogs, record, nil, pollTime, target, marks)
+	}
+
+	if skipped > 0 {
+		r.telemetry.recordSkippedLines(ctx, target, skipped)
+		r.logger.Warn("Skipped CSV rows with an unexpected number of fields",
+			zap.String("endpoint", target.Endpoint),
+			zap.Int("skipped_lines", skipped))
+	}
+
+	return logs, nil
+}
+
+// csvValue converts a field to the type of its column. Values that cannot be
+// converted are kept as strings.
+func (r *logsReceiver) csvValue(value, columnType, column string, target *targetConfig) interface{} {
+	var (
+		converted interface{}
+		err       error
+	)
+
+	switch columnType {
+	case columnTypeInt:
+		converted, err = strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	case columnTypeFloat:
+		converted, err = strconv.ParseFloat(strings.TrimSpace(value), 64)
+	case columnTypeBool:
+		converted, err = strconv.ParseBool(strings.TrimSpace(value))
+	default:
+		return value
+	}
+
+	if err != nil {
+		r.logger.Debug("Failed to convert CSV value",
+			zap.String("endpoint", target.Endpoint),
+			zap.String("column", column),
+			zap.String("type", columnType),
+			zap.Error(err))
+		return value
+	}
+
+	return converted
+}
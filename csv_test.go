@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// pollBodies polls the target once and returns the bodies and attributes of its records.
+func pollBodies(t *testing.T, r *logsReceiver, target *targetConfig) ([]map[string]interface{}, []map[string]interface{}) {
+	sink := &testLogsSink{}
+	r.consumer = sink
+	require.NoError(t, r.pollTarget(context.Background(), target))
+
+	var bodies, attrs []map[string]interface{}
+	for _, logs := range sink.AllLogs() {
+		records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		for i := 0; i < records.Len(); i++ {
+			bodies = append(bodies, records.At(i).Body().Map().AsRaw())
+			attrs = append(attrs, records.At(i).Attributes().AsRaw())
+		}
+	}
+	return bodies, attrs
+}
+
+func TestLogsReceiver_CSV(t *testing.T) {
+	const report = "\ufeffuser, action ,count,ok\n" +
+		"alice,login,3,true\n" +
+		"bob,\"note, with comma\nand a second line\",x,false\n" +
+		"broken,row\n" +
+		"carol,logout,1,yes\n"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		_, _ = w.Write([]byte(report))
+	}))
+	defer srv.Close()
+
+	tel := componenttest.NewTelemetry()
+	defer func() { require.NoError(t, tel.Shutdown(context.Background())) }()
+	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
+	settings.TelemetrySettings = tel.NewTelemetrySettings()
+
+	target := &targetConfig{
+		ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL},
+		Labels:       map[string]string{"user": "user"},
+		CSV:          &csvConfig{Types: map[string]string{"count": "int", "ok": "bool"}},
+	}
+	require.NoError(t, target.Validate())
+
+	r := newLogsReceiver(&Config{Targets: []*targetConfig{target}}, settings, nil)
+	r.telemetry, _ = newReceiverTelemetry(r)
+	defer func() { require.NoError(t, r.telemetry.shutdown()) }()
+	bodies, attrs := pollBodies(t, r, target)
+
+	assert.Equal(t, []map[string]interface{}{
+		{"user": "alice", "action": "login", "count": int64(3), "ok": true},
+		{"user": "bob", "action": "note, with comma\nand a second line", "count": "x", "ok": false},
+		{"user": "carol", "action": "logout", "count": int64(1), "ok": "yes"},
+	}, bodies)
+	assert.Equal(t, []map[string]interface{}{{"user": "alice"}, {"user": "bob"}, {"user": "carol"}}, attrs)
+	assert.Equal(t, int64(1), sumValue(t, tel, "otelcol_logsreceiver_target_skipped_lines", attribute.String(targetAttribute, srv.URL)))
+}
+
+func TestLogsReceiver_TSVAndDelimiters(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		target      targetConfig
+		want        []map[string]interface{}
+	}{
+		{
+			name:        "tsv with configured header",
+			contentType: "text/tab-separated-values",
+			body:        "2025-10-15T10:00:00Z\terror\tdisk full\n2025-10-15T10:00:01Z\tinfo\tok\n",
+			target:      targetConfig{CSV: &csvConfig{Header: []string{"ts", "level", "msg"}}},
+			want: []map[string]interface{}{
+				{"ts": "2025-10-15T10:00:00Z", "level": "error", "msg": "disk full"},
+				{"ts": "2025-10-15T10:00:01Z", "level": "info", "msg": "ok"},
+			},
+		},
+		{
+			name:        "custom delimiter with format override",
+			contentType: "application/octet-stream",
+			body:        "id;amount\n1;2.5\n",
+			target:      targetConfig{Format: "csv", CSV: &csvConfig{Delimiter: ";", Types: map[string]string{"amount": "float"}}},
+			want:        []map[string]interface{}{{"id": "1", "amount": 2.5}},
+		},
+		{
+			name:        "header only",
+			contentType: "text/csv",
+			body:        "id,amount\n",
+			target:      targetConfig{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			target := tt.target
+			target.Endpoint = srv.URL
+			require.NoError(t, target.Validate())
+
+			r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), nil)
+			bodies, _ := pollBodies(t, r, &target)
+			assert.Equal(t, tt.want, bodies)
+		})
+	}
+}
+
+func TestLogsReceiver_CSVTimestampAndSeverity(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = w.Write([]byte("ts,level\n2025-10-15T10:00:00Z,warn\n"))
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{
+		ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL},
+		Timestamp:    &timestampConfig{Field: "ts"},
+		Severity:     &severityConfig{Field: "level"},
+	}
+	require.NoError(t, target.Validate())
+
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), sink)
+	require.NoError(t, r.pollTarget(context.Background(), target))
+
+	record := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "2025-10-15T10:00:00Z", record.Timestamp().AsTime().Format("2006-01-02T15:04:05Z07:00"))
+	assert.Equal(t, plog.SeverityNumberWarn, record.SeverityNumber())
+}
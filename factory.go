@@ -28,14 +28,16 @@ func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		component.MustNewType(typeStr),
 		createDefaultConfig,
-		receiver.WithLogs(createLogsReceiver, stability))
+		receiver.WithLogs(createLogsReceiver, stability),
+		receiver.WithMetrics(createMetricsReceiver, stability))
 }
 
 // createDefaultConfig creates the default configuration for the logs receiver.
 func createDefaultConfig() component.Config {
 	return &Config{
-		CollectionInterval: 30 * time.Second,
-		Targets:            []*targetConfig{},
+		CollectionInterval:   30 * time.Second,
+		Targets:              []*targetConfig{},
+		AuthFailureThreshold: 3,
 	}
 }
 
@@ -46,6 +48,20 @@ func createLogsReceiver(_ context.Context, params receiver.Settings, rConf compo
 		return nil, errConfigNotLogsReceiver
 	}
 
-	logsReceiver := newLogsReceiver(cfg, params, consumer)
-	return logsReceiver, nil
+	shared := receivers.getOrCreate(cfg, params)
+	shared.consumer = consumer
+	return shared, nil
+}
+
+// createMetricsReceiver creates a receiver emitting the health of the polls
+// of the provided config. It shares its poll loop with the logs receiver of the same config.
+func createMetricsReceiver(_ context.Context, params receiver.Settings, rConf component.Config, consumer consumer.Metrics) (receiver.Metrics, error) {
+	cfg, ok := rConf.(*Config)
+	if !ok {
+		return nil, errConfigNotLogsReceiver
+	}
+
+	shared := receivers.getOrCreate(cfg, params)
+	shared.metricsConsumer = consumer
+	return shared, nil
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"testing"
 
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 )
@@ -41,8 +42,8 @@ func TestFactory_CreateLogsReceiver(t *testing.T) {
 	logsConfig := cfg.(*Config)
 	logsConfig.Targets = []*targetConfig{
 		{
-			Endpoint: "http://example.com/logs",
-			Method:   "GET",
+			ClientConfig: confighttp.ClientConfig{Endpoint: "http://example.com/logs"},
+			Method:       "GET",
 		},
 	}
 
@@ -59,3 +60,45 @@ func TestFactory_CreateLogsReceiver(t *testing.T) {
 		t.Fatal("CreateLogs() returned nil receiver")
 	}
 }
+
+func TestFactory_SharesReceiverAcrossSignals(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Targets = []*targetConfig{
+		{ClientConfig: confighttp.ClientConfig{Endpoint: "http://example.com/logs"}},
+	}
+
+	ctx := context.Background()
+	settings := receivertest.NewNopSettings(factory.Type())
+
+	logs, err := factory.CreateLogs(ctx, settings, cfg, consumertest.NewNop())
+	if err != nil {
+		t.Fatalf("CreateLogs() failed: %v", err)
+	}
+	metrics, err := factory.CreateMetrics(ctx, settings, cfg, consumertest.NewNop())
+	if err != nil {
+		t.Fatalf("CreateMetrics() failed: %v", err)
+	}
+	if logs != metrics {
+		t.Fatal("expected logs and metrics to share a receiver for the same config")
+	}
+
+	if err := logs.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() failed: %v", err)
+	}
+	if err := metrics.Shutdown(ctx); err != nil {
+		t.Fatalf("second Shutdown() failed: %v", err)
+	}
+
+	// a shut down receiver is forgotten, so a restarted pipeline gets a fresh one
+	again, err := factory.CreateLogs(ctx, settings, cfg, consumertest.NewNop())
+	if err != nil {
+		t.Fatalf("CreateLogs() failed: %v", err)
+	}
+	if again == logs {
+		t.Fatal("expected a new receiver after shutdown")
+	}
+	if err := again.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() failed: %v", err)
+	}
+}
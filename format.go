@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// sniffLen is the number of leading body bytes inspected to detect the format.
+const sniffLen = 512
+
+// genericContentTypes are media types that do not tell the format of a
+// response apart, so the body is sniffed instead.
+var genericContentTypes = map[string]bool{
+	"":                         true,
+	"application/octet-stream": true,
+	"binary/octet-stream":      true,
+	"text/plain":               true,
+	"text/html":                true,
+}
+
+// responseFormat returns the format a response is parsed as. A format set on
+// the target wins; otherwise a specific Content-Type decides, and a missing
+// or generic one falls back to sniffing the start of the body, which is
+// peeked without being consumed. A JSON or NDJSON body that starts with an
+// OTLP/JSON request is OTLP/JSON, however it is served.
+func responseFormat(target *targetConfig, resp *http.Response, body *bufio.Reader) string {
+	if target.Format != "" && target.Format != formatAuto {
+		return target.Format
+	}
+
+	// A short body is returned together with io.EOF, and read errors surface again when the body is read
+	prefix, _ := body.Peek(sniffLen)
+
+	format := contentTypeFormat(resp.Header.Get("Content-Type"))
+	if format == formatAuto {
+		format = sniffFormat(prefix)
+	}
+
+	// Such as the file exporter's output of one request per line, served as text or NDJSON
+	if (format == formatJSON || format == formatNDJSON) && target.RecordsPath == "" && target.Streaming == nil && hasOTLPJSONPrefix(prefix) {
+		return formatOTLPJSON
+	}
+	return format
+}
+
+// contentTypeFormat returns the format named by a Content-Type header, or
+// formatAuto when the header is missing or generic.
+func contentTypeFormat(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+
+	switch {
+	case genericContentTypes[mediaType]:
+		return formatAuto
+	case isNDJSON(mediaType):
+		return formatNDJSON
+	case strings.Contains(mediaType, "application/json") || strings.HasSuffix(mediaType, "+json"):
+		return formatJSON
+	case mediaType == "application/x-protobuf" || mediaType == "application/protobuf":
+		return formatOTLPProto
+	case strings.HasSuffix(mediaType, "/xml") || strings.HasSuffix(mediaType, "+xml"):
+		return formatXML
+	case mediaType == "text/tab-separated-values":
+		return formatTSV
+	case strings.Contains(mediaType, "csv"):
+		return formatCSV
+	default:
+		return formatText
+	}
+}
+
+// sniffFormat detects the format of a body from its first bytes. A body that
+// starts with a single JSON value is JSON, one with a JSON value per line is
+// NDJSON, one that starts with an XML declaration or element other than an
+// HTML document is XML, and anything else is text.
+func sniffFormat(prefix []byte) string {
+	prefix = bytes.TrimLeft(prefix, " \t\r\n\ufeff")
+	if isXMLPrefix(prefix) {
+		return formatXML
+	}
+	if len(prefix) == 0 || (prefix[0] != '{' && prefix[0] != '[') {
+		return formatText
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(prefix))
+	var first json.RawMessage
+	switch err := dec.Decode(&first); {
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		// A document larger than the prefix
+		return formatJSON
+	case err != nil:
+		return formatText
+	}
+
+	// Further values must start on a new line
+	rest := bytes.TrimLeft(prefix[dec.InputOffset():], " \t\r")
+	switch {
+	case len(bytes.TrimSpace(rest)) == 0:
+		return formatJSON
+	case rest[0] == '\n':
+		return formatNDJSON
+	default:
+		return formatText
+	}
+}
+
+// isXMLPrefix reports whether a trimmed body starts like an XML document.
+func isXMLPrefix(prefix []byte) bool {
+	lower := bytes.ToLower(prefix)
+	switch {
+	case bytes.HasPrefix(lower, []byte("<?xml")):
+		return true
+	case bytes.HasPrefix(lower, []byte("<html")), bytes.HasPrefix(lower, []byte("<!doctype html")):
+		return false
+	case bytes.HasPrefix(lower, []byte("<!--")), bytes.HasPrefix(lower, []byte("<!doctype")):
+		return true
+	default:
+		// An element name starts with a letter, unlike e.g. a syslog priority such as <34>
+		return len(lower) > 1 && lower[0] == '<' && (lower[1] >= 'a' && lower[1] <= 'z' || lower[1] == '_')
+	}
+}
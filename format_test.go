@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestContentTypeFormat(t *testing.T) {
+	tests := map[string]string{
+		"application/json":                "json",
+		"application/json; charset=utf-8": "json",
+		"application/vnd.api+json":        "json",
+		"application/x-ndjson":            "ndjson",
+		"application/jsonl":               "ndjson",
+		"application/jsonlines":           "ndjson",
+		"text/csv":                        "csv",
+		"application/csv":                 "csv",
+		"text/tab-separated-values":       "tsv",
+		"application/xml":                 "xml",
+		"text/xml; charset=utf-8":         "xml",
+		"application/soap+xml":            "xml",
+		"application/pdf":                 "text",
+		"application/x-protobuf":          "otlp_proto",
+		"text/plain; charset=utf-8":       "auto",
+		"text/html":                       "auto",
+		"application/octet-stream":        "auto",
+		"":                                "auto",
+	}
+
+	for contentType, want := range tests {
+		assert.Equal(t, want, contentTypeFormat(contentType), contentType)
+	}
+}
+
+func TestSniffFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		want   string
+	}{
+		{name: "empty", prefix: "", want: "text"},
+		{name: "plain lines", prefix: "alpha\nbeta\n", want: "text"},
+		{name: "bracketed text", prefix: "[2025-10-15 10:00:00] ERROR failed\n", want: "text"},
+		{name: "braced text", prefix: "{user} logged in\n", want: "text"},
+		{name: "object", prefix: `{"a":1}`, want: "json"},
+		{name: "array with BOM and whitespace", prefix: "\ufeff\n  [{\"a\":1},\n{\"a\":2}]\n", want: "json"},
+		{name: "pretty-printed object", prefix: "{\n  \"a\": 1\n}\n", want: "json"},
+		{name: "truncated document", prefix: `{"records":[{"a":1},{"a":`, want: "json"},
+		{name: "json lines", prefix: "{\"a\":1}\n{\"a\":2}\n", want: "ndjson"},
+		{name: "truncated json lines", prefix: "{\"a\":1}\r\n{\"a\":", want: "ndjson"},
+		{name: "value followed by text", prefix: `[1] done`, want: "text"},
+		{name: "xml declaration", prefix: `<?xml version="1.0"?><Events/>`, want: "xml"},
+		{name: "xml element", prefix: "\n<Events><Event id=\"1\"/></Events>", want: "xml"},
+		{name: "html document", prefix: "<!DOCTYPE html><html><body>oops</body></html>", want: "text"},
+		{name: "syslog priority", prefix: "<34>Oct 11 22:14:15 host su: failed", want: "text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sniffFormat([]byte(tt.prefix)))
+		})
+	}
+}
+
+func TestLogsReceiver_Format(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		format      string
+		wantBodies  []interface{}
+	}{
+		{
+			name:        "json sniffed from octet-stream",
+			contentType: "application/octet-stream",
+			body:        `[{"msg":"a"},{"msg":"b"}]`,
+			wantBodies:  []interface{}{map[string]interface{}{"msg": "a"}, map[string]interface{}{"msg": "b"}},
+		},
+		{
+			name:       "ndjson sniffed without content type",
+			body:       "{\"msg\":\"a\"}\n{\"msg\":\"b\"}\n",
+			wantBodies: []interface{}{map[string]interface{}{"msg": "a"}, map[string]interface{}{"msg": "b"}},
+		},
+		{
+			name:        "json overrides wrong content type",
+			contentType: "text/csv",
+			body:        `{"msg":"a"}`,
+			format:      "json",
+			wantBodies:  []interface{}{map[string]interface{}{"msg": "a"}},
+		},
+		{
+			name:        "text overrides json content type",
+			contentType: "application/json",
+			body:        "{\"msg\":\"a\"}\n{\"msg\":\"b\"}",
+			format:      "text",
+			wantBodies:  []interface{}{`{"msg":"a"}`, `{"msg":"b"}`},
+		},
+		{
+			name:        "large json sniffed from its prefix",
+			contentType: "text/html",
+			body:        `{"msg":"` + strings.Repeat("x", 2*sniffLen) + `"}`,
+			wantBodies:  []interface{}{map[string]interface{}{"msg": strings.Repeat("x", 2*sniffLen)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				// An empty value keeps the server from sniffing a Content-Type of its own
+				w.Header()["Content-Type"] = []string{tt.contentType}
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}, Format: tt.format}
+			require.NoError(t, target.Validate())
+
+			sink := &testLogsSink{}
+			r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), sink)
+			require.NoError(t, r.pollTarget(context.Background(), target))
+
+			logs := sink.AllLogs()
+			require.Len(t, logs, 1)
+			records := logs[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+			var bodies []interface{}
+			for i := 0; i < records.Len(); i++ {
+				bodies = append(bodies, records.At(i).Body().AsRaw())
+			}
+			assert.Equal(t, tt.wantBodies, bodies)
+		})
+	}
+}
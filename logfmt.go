@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// Keys that set the timestamp and severity of logfmt records unless the
+// target configures its own timestamp and severity fields
+var (
+	logfmtTimestamps = []*timestampConfig{
+		{Field: "time", LayoutType: layoutTypeGoTime, Layout: time.RFC3339Nano},
+		{Field: "ts", LayoutType: layoutTypeGoTime, Layout: time.RFC3339Nano},
+	}
+	logfmtSeverity = &severityConfig{Field: "level"}
+)
+
+// parseLogfmt parses a logfmt line of key=value pairs. Values are bare or
+// double-quoted with Go string escapes, and a key without a value gets an
+// empty one. A line without any key=value pair is not logfmt.
+func parseLogfmt(line string) (map[string]interface{}, error) {
+	record := map[string]interface{}{}
+	pairs := 0
+
+	for i := 0; i < len(line); {
+		if line[i] == ' ' || line[i] == '\t' {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(line) && line[i] > ' ' && line[i] != '=' && line[i] != '"' {
+			i++
+		}
+		if i == start {
+			return nil, fmt.Errorf("unexpected %q at offset %d", line[i], i)
+		}
+		key := line[start:i]
+
+		if i == len(line) || line[i] != '=' {
+			record[key] = ""
+			continue
+		}
+		i++
+		pairs++
+
+		if i < len(line) && line[i] == '"' {
+			end := i + 1
+			for end < len(line) && line[end] != '"' {
+				if line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(line) {
+				return nil, fmt.Errorf("unterminated quoted value of %q", key)
+			}
+			value, err := strconv.Unquote(line[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted value of %q: %w", key, err)
+			}
+			record[key] = value
+			i = end + 1
+			continue
+		}
+
+		start = i
+		for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+			i++
+		}
+		record[key] = line[start:i]
+	}
+
+	if pairs == 0 {
+		return nil, errors.New("no key=value pairs")
+	}
+
+	return record, nil
+}
+
+// parseLogfmtLogs parses logfmt lines into records with a map body. Lines
+// that are not logfmt are kept as plain text records.
+func (r *logsReceiver) parseLogfmtLogs(body []byte, target *targetConfig, pollTime time.Time, logs plog.Logs, marks *recordMarks) (plog.Logs, error) {
+	scopeLogs := appendTargetScopeLogs(logs, target)
+
+	for _, line := range splitTextEntries(string(body), target.Multiline) {
+		record, err := parseLogfmt(line)
+		if err != nil {
+			r.addTextRecord(scopeLogs, line, pollTime, target)
+			continue
+		}
+
+		logRecord := r.addLogRecord(scopeLogs, record, nil, pollTime, target, marks)
+		if target.Timestamp == nil {
+			for _, cfg := range logfmtTimestamps {
+				if _, ok := record[cfg.Field]; ok {
+					ts, _ := r.recordTimestamp(record, pollTime, cfg, target)
+					logRecord.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+					break
+				}
+			}
+		}
+		if target.Severity == nil {
+			r.setSeverity(logRecord, record, logfmtSeverity, target)
+		}
+	}
+
+	return logs, nil
+}
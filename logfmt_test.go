@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestParseLogfmt(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "bare and quoted values",
+			line: `level=info msg="user signed in" user=42 path=/login`,
+			want: map[string]interface{}{"level": "info", "msg": "user signed in", "user": "42", "path": "/login"},
+		},
+		{
+			name: "escapes",
+			line: `msg="say \"hi\"\tthen\\leave" empty="" unicode="café"`,
+			want: map[string]interface{}{"msg": "say \"hi\"\tthen\\leave", "empty": "", "unicode": "café"},
+		},
+		{
+			name: "bare keys and empty values",
+			line: "debug at=  retry  n=3",
+			want: map[string]interface{}{"debug": "", "at": "", "retry": "", "n": "3"},
+		},
+		{
+			name: "values containing equals signs",
+			line: "query=a=b&c=d",
+			want: map[string]interface{}{"query": "a=b&c=d"},
+		},
+		{name: "plain text", line: "panic: runtime error", wantErr: true},
+		{name: "unterminated quote", line: `msg="never closed`, wantErr: true},
+		{name: "invalid escape", line: `msg="bad \q"`, wantErr: true},
+		{name: "quote without key", line: `"quoted" a=b`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record, err := parseLogfmt(tt.line)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, record)
+		})
+	}
+}
+
+func TestLogsReceiver_Logfmt(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(`time=2025-10-15T10:00:00Z level=error msg="payment failed" user=42
+ts=2025-10-15T10:00:01.5Z level=warn msg=retrying user=42
+panic: something went wrong
+`))
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{
+		ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL},
+		Format:       "logfmt",
+		Labels:       map[string]string{"user": "user"},
+	}
+	require.NoError(t, target.Validate())
+
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), sink)
+	require.NoError(t, r.pollTarget(context.Background(), target))
+
+	records := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 3, records.Len())
+
+	first := records.At(0)
+	assert.Equal(t, map[string]interface{}{"time": "2025-10-15T10:00:00Z", "level": "error", "msg": "payment failed", "user": "42"}, first.Body().Map().AsRaw())
+	assert.Equal(t, map[string]interface{}{"user": "42"}, first.Attributes().AsRaw())
+	assert.Equal(t, time.Date(2025, 10, 15, 10, 0, 0, 0, time.UTC), first.Timestamp().AsTime())
+	assert.Equal(t, plog.SeverityNumberError, first.SeverityNumber())
+
+	second := records.At(1)
+	assert.Equal(t, time.Date(2025, 10, 15, 10, 0, 1, 500000000, time.UTC), second.Timestamp().AsTime())
+	assert.Equal(t, plog.SeverityNumberWarn, second.SeverityNumber())
+
+	third := records.At(2)
+	assert.Equal(t, "panic: something went wrong", third.Body().Str())
+}
+
+func TestLogsReceiver_LogfmtConfiguredFields(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("time=ignored at=1760522400 level=info lvl=E msg=x\n"))
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{
+		ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL},
+		Format:       "logfmt",
+		Timestamp:    &timestampConfig{Field: "at", LayoutType: layoutTypeEpoch},
+		Severity:     &severityConfig{Field: "lvl", Mapping: map[string][]interface{}{"error": {"E"}}},
+	}
+	require.NoError(t, target.Validate())
+
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), sink)
+	require.NoError(t, r.pollTarget(context.Background(), target))
+
+	record := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, time.Unix(1760522400, 0).UTC(), record.Timestamp().AsTime())
+	assert.Equal(t, plog.SeverityNumberError, record.SeverityNumber())
+}
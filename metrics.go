@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// pollStats summarizes the requests made by a single poll of a target.
+type pollStats struct {
+	// Status code of the last response, or 0 when no response was received
+	statusCode int
+
+	// Log records produced across all pages
+	records int
+
+	// Response body bytes received across all pages
+	bytes int
+}
+
+// buildPollMetrics converts the outcome of a poll into the target health
+// metrics. A nil stats marks a poll skipped by an open circuit breaker, for
+// which only logsreceiver.target.up is emitted, so the outage stays visible.
+func buildPollMetrics(target *targetConfig, pollTime, endTime time.Time, stats *pollStats, pollErr error) pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+
+	resourceMetrics := metrics.ResourceMetrics().AppendEmpty()
+	resource := resourceMetrics.Resource()
+	resource.Attributes().PutStr("endpoint", target.Endpoint)
+	resource.Attributes().PutStr("service.name", target.ServiceName)
+
+	scopeMetrics := resourceMetrics.ScopeMetrics().AppendEmpty()
+	scopeMetrics.Scope().SetName(scopeName)
+
+	start := pcommon.NewTimestampFromTime(pollTime)
+	end := pcommon.NewTimestampFromTime(endTime)
+	setStatusCode := func(attrs pcommon.Map) {
+		if stats != nil && stats.statusCode != 0 {
+			attrs.PutInt(statusCodeAttribute, int64(stats.statusCode))
+		}
+	}
+
+	up := scopeMetrics.Metrics().AppendEmpty()
+	up.SetName("logsreceiver.target.up")
+	up.SetDescription("Whether the last poll of the target succeeded (1) or failed (0)")
+	up.SetUnit("1")
+	dp := up.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(end)
+	dp.SetIntValue(0)
+	if pollErr == nil {
+		dp.SetIntValue(1)
+	}
+	setStatusCode(dp.Attributes())
+
+	if stats == nil {
+		return metrics
+	}
+
+	duration := scopeMetrics.Metrics().AppendEmpty()
+	duration.SetName("logsreceiver.request.duration")
+	duration.SetDescription("Duration of the poll, including all pages and retries")
+	duration.SetUnit("s")
+	dp = duration.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(end)
+	dp.SetDoubleValue(endTime.Sub(pollTime).Seconds())
+	setStatusCode(dp.Attributes())
+
+	records := scopeMetrics.Metrics().AppendEmpty()
+	records.SetName("logsreceiver.records")
+	records.SetDescription("Number of log records produced by the poll")
+	records.SetUnit("{record}")
+	sum := records.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	sum.SetIsMonotonic(true)
+	dp = sum.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(end)
+	dp.SetIntValue(int64(stats.records))
+	setStatusCode(dp.Attributes())
+
+	size := scopeMetrics.Metrics().AppendEmpty()
+	size.SetName("logsreceiver.response.size")
+	size.SetDescription("Response body bytes received by the poll")
+	size.SetUnit("By")
+	sum = size.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	sum.SetIsMonotonic(true)
+	dp = sum.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(end)
+	dp.SetIntValue(int64(stats.bytes))
+	setStatusCode(dp.Attributes())
+
+	return metrics
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestLogsReceiver_PollMetrics(t *testing.T) {
+	const payload = `[{"msg":"a"},{"msg":"b"},{"msg":"c"}]`
+	var status int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}}
+	require.NoError(t, target.Validate())
+
+	sink := &consumertest.MetricsSink{}
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), nil)
+	r.metricsConsumer = sink
+
+	status = http.StatusOK
+	require.NoError(t, r.pollTarget(context.Background(), target))
+	status = http.StatusServiceUnavailable
+	require.Error(t, r.pollTarget(context.Background(), target))
+
+	all := sink.AllMetrics()
+	require.Len(t, all, 2)
+
+	ok := pollMetricsByName(all[0])
+	resource := all[0].ResourceMetrics().At(0).Resource().Attributes()
+	endpoint, _ := resource.Get("endpoint")
+	assert.Equal(t, srv.URL, endpoint.Str())
+	assert.Equal(t, int64(1), ok["logsreceiver.target.up"].Gauge().DataPoints().At(0).IntValue())
+	assert.Equal(t, int64(3), ok["logsreceiver.records"].Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, int64(len(payload)), ok["logsreceiver.response.size"].Sum().DataPoints().At(0).IntValue())
+	duration := ok["logsreceiver.request.duration"].Gauge().DataPoints().At(0)
+	assert.Positive(t, duration.DoubleValue())
+	code, _ := duration.Attributes().Get(statusCodeAttribute)
+	assert.Equal(t, int64(http.StatusOK), code.Int())
+
+	failed := pollMetricsByName(all[1])
+	up := failed["logsreceiver.target.up"].Gauge().DataPoints().At(0)
+	assert.Equal(t, int64(0), up.IntValue())
+	code, _ = up.Attributes().Get(statusCodeAttribute)
+	assert.Equal(t, int64(http.StatusServiceUnavailable), code.Int())
+	assert.Equal(t, int64(0), failed["logsreceiver.records"].Sum().DataPoints().At(0).IntValue())
+}
+
+func TestBuildPollMetrics_NoResponse(t *testing.T) {
+	target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: "http://unreachable"}, ServiceName: "svc"}
+	pollTime := time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	metrics := buildPollMetrics(target, pollTime, pollTime.Add(2*time.Second), &pollStats{}, context.DeadlineExceeded)
+	byName := pollMetricsByName(metrics)
+	require.Len(t, byName, 4)
+
+	up := byName["logsreceiver.target.up"].Gauge().DataPoints().At(0)
+	assert.Equal(t, int64(0), up.IntValue())
+	_, hasCode := up.Attributes().Get(statusCodeAttribute)
+	assert.False(t, hasCode)
+	assert.InDelta(t, 2.0, byName["logsreceiver.request.duration"].Gauge().DataPoints().At(0).DoubleValue(), 0)
+	records := byName["logsreceiver.records"].Sum()
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, records.AggregationTemporality())
+	assert.Equal(t, pollTime, records.DataPoints().At(0).StartTimestamp().AsTime())
+}
+
+func TestLogsReceiver_PollMetricsCircuitOpen(t *testing.T) {
+	target := &targetConfig{
+		ClientConfig:   confighttp.ClientConfig{Endpoint: "http://unreachable"},
+		CircuitBreaker: &circuitBreakerConfig{FailureThreshold: 1, OpenDuration: time.Hour},
+	}
+	require.NoError(t, target.Validate())
+
+	sink := &consumertest.MetricsSink{}
+	r := newLogsReceiver(&Config{Targets: []*targetConfig{target}}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), nil)
+	r.metricsConsumer = sink
+	breaker := r.breakers[target]
+	breaker.record(errCircuitOpen, time.Now())
+	require.Equal(t, circuitOpen, breaker.currentState())
+
+	// a skipped poll still reports the target as down
+	r.scheduledPoll(context.Background(), target, breaker)
+
+	all := sink.AllMetrics()
+	require.Len(t, all, 1)
+	byName := pollMetricsByName(all[0])
+	require.Len(t, byName, 1)
+	assert.Equal(t, int64(0), byName["logsreceiver.target.up"].Gauge().DataPoints().At(0).IntValue())
+}
+
+// pollMetricsByName indexes the metrics of a single poll by name.
+func pollMetricsByName(metrics pmetric.Metrics) map[string]pmetric.Metric {
+	byName := map[string]pmetric.Metric{}
+	scopeMetrics := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < scopeMetrics.Len(); i++ {
+		byName[scopeMetrics.At(i).Name()] = scopeMetrics.At(i)
+	}
+	return byName
+}
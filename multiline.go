@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// splitTextEntries splits a text body into the entries that become log
+// records. Without a multiline config every non-empty line is an entry, with
+// surrounding whitespace trimmed.
+func splitTextEntries(body string, cfg *multilineConfig) []string {
+	var entries []string
+
+	if cfg == nil {
+		for _, line := range strings.Split(body, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				entries = append(entries, line)
+			}
+		}
+		return entries
+	}
+
+	var (
+		current []string
+		size    int
+	)
+	flush := func() {
+		// Blank lines only separate entries at their end
+		for len(current) > 0 && strings.TrimSpace(current[len(current)-1]) == "" {
+			current = current[:len(current)-1]
+		}
+		if len(current) > 0 {
+			entries = append(entries, strings.Join(current, "\n"))
+		}
+		current, size = nil, 0
+	}
+
+	for _, raw := range strings.Split(body, "\n") {
+		raw = strings.TrimSuffix(raw, "\r")
+
+		// Blank lines never start an entry
+		if len(current) == 0 && strings.TrimSpace(raw) == "" {
+			continue
+		}
+
+		if cfg.lineStart != nil && len(current) > 0 && cfg.lineStart.MatchString(raw) {
+			flush()
+		}
+
+		line := strings.TrimSpace(raw)
+		if cfg.PreserveLeadingWhitespace {
+			line = strings.TrimRight(raw, " \t")
+		}
+
+		// A line longer than max_bytes is cut into entries of its own, and its
+		// rest starts the next one
+		if len(line) > cfg.MaxBytes {
+			flush()
+			for len(line) > cfg.MaxBytes {
+				cut := runeBoundary(line, cfg.MaxBytes)
+				entries = append(entries, line[:cut])
+				line = line[cut:]
+			}
+		}
+
+		// Entries that reach a cap are cut, and the line starts the next one
+		if len(current) > 0 && (len(current) >= cfg.MaxLines || size+1+len(line) > cfg.MaxBytes) {
+			flush()
+		}
+		if len(current) > 0 {
+			size++
+		}
+		current = append(current, line)
+		size += len(line)
+
+		if cfg.lineEnd != nil && cfg.lineEnd.MatchString(raw) {
+			flush()
+		}
+	}
+	flush()
+
+	return entries
+}
+
+// runeBoundary returns the largest cut of s at most n bytes long that does not
+// split a UTF-8 sequence, or n when the first rune is longer than that.
+func runeBoundary(s string, n int) int {
+	for cut := n; cut > 0; cut-- {
+		if utf8.RuneStart(s[cut]) {
+			return cut
+		}
+	}
+	return n
+}
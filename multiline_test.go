@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+const stackTraceLog = `2025-10-15 10:00:00 INFO Starting admin endpoint
+2025-10-15 10:00:01 ERROR Request failed
+java.lang.IllegalStateException: connection closed
+	at com.example.Client.send(Client.java:42)
+	at com.example.Admin.handle(Admin.java:7)
+
+Caused by: java.io.IOException: broken pipe
+	at com.example.Socket.write(Socket.java:99)
+
+2025-10-15 10:00:02 INFO Recovered
+`
+
+func TestSplitTextEntries(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		cfg  *multilineConfig
+		want []string
+	}{
+		{
+			name: "single lines",
+			body: "alpha\n  beta  \r\n\ngamma",
+			want: []string{"alpha", "beta", "gamma"},
+		},
+		{
+			name: "line start pattern",
+			body: stackTraceLog,
+			cfg:  &multilineConfig{LineStartPattern: `^\d{4}-\d{2}-\d{2} `},
+			want: []string{
+				"2025-10-15 10:00:00 INFO Starting admin endpoint",
+				"2025-10-15 10:00:01 ERROR Request failed\njava.lang.IllegalStateException: connection closed\nat com.example.Client.send(Client.java:42)\nat com.example.Admin.handle(Admin.java:7)\n\nCaused by: java.io.IOException: broken pipe\nat com.example.Socket.write(Socket.java:99)",
+				"2025-10-15 10:00:02 INFO Recovered",
+			},
+		},
+		{
+			name: "preserved leading whitespace",
+			body: "first\r\n\tindented  \r\n    more\nsecond\n",
+			cfg:  &multilineConfig{LineStartPattern: `^\S`, PreserveLeadingWhitespace: true},
+			want: []string{"first\n\tindented\n    more", "second"},
+		},
+		{
+			name: "line end pattern",
+			body: "BEGIN\n  a\nEND;\n\nBEGIN\nb\nEND;\ntrailing",
+			cfg:  &multilineConfig{LineEndPattern: `;$`},
+			want: []string{"BEGIN\na\nEND;", "BEGIN\nb\nEND;", "trailing"},
+		},
+		{
+			name: "lines before the first start",
+			body: "  orphan\nSTART one\ncontinued",
+			cfg:  &multilineConfig{LineStartPattern: `^START`},
+			want: []string{"orphan", "START one\ncontinued"},
+		},
+		{
+			name: "max lines",
+			body: "START\n1\n2\n3\n4",
+			cfg:  &multilineConfig{LineStartPattern: `^START`, MaxLines: 2},
+			want: []string{"START\n1", "2\n3", "4"},
+		},
+		{
+			name: "max bytes",
+			body: "START\naaaa\nbbbb\ncccccccccccc",
+			cfg:  &multilineConfig{LineStartPattern: `^START`, MaxBytes: 10},
+			want: []string{"START\naaaa", "bbbb", "cccccccccc", "cc"},
+		},
+		{
+			name: "line longer than max bytes",
+			body: "START\n0123456789abcdefghijklmnopqrstuvwxyz\nSTART again",
+			cfg:  &multilineConfig{LineStartPattern: `^START`, MaxBytes: 16},
+			want: []string{"START", "0123456789abcdef", "ghijklmnopqrstuv", "wxyz", "START again"},
+		},
+		{
+			name: "max bytes keeps characters whole",
+			body: "ééééé",
+			cfg:  &multilineConfig{LineStartPattern: `^\S`, MaxBytes: 3},
+			want: []string{"é", "é", "é", "é", "é"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.cfg != nil {
+				require.NoError(t, tt.cfg.Validate())
+			}
+			assert.Equal(t, tt.want, splitTextEntries(tt.body, tt.cfg))
+		})
+	}
+}
+
+func TestLogsReceiver_Multiline(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(stackTraceLog))
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{
+		ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL},
+		Multiline:    &multilineConfig{LineStartPattern: `^\d{4}-\d{2}-\d{2} `, PreserveLeadingWhitespace: true},
+		Regex:        &regexConfig{Pattern: `(?s)^\S+ \S+ (?P<severity>[A-Z]+) (?P<body>.*)$`},
+	}
+	require.NoError(t, target.Validate())
+
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), sink)
+	require.NoError(t, r.pollTarget(context.Background(), target))
+
+	records := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 3, records.Len())
+
+	trace := records.At(1)
+	assert.Equal(t, "ERROR", trace.SeverityText())
+	assert.Equal(t, "Request failed\njava.lang.IllegalStateException: connection closed\n\tat com.example.Client.send(Client.java:42)\n\tat com.example.Admin.handle(Admin.java:7)\n\nCaused by: java.io.IOException: broken pipe\n\tat com.example.Socket.write(Socket.java:99)", trace.Body().Str())
+}
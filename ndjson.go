@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+// isNDJSON reports whether a lowercased Content-Type denotes newline-delimited
+// JSON, e.g. application/x-ndjson, application/jsonl or application/jsonlines.
+func isNDJSON(contentType string) bool {
+	return strings.Contains(contentType, "ndjson") || strings.Contains(contentType, "jsonl")
+}
+
+// parseNDJSONLogs parses newline-delimited JSON, where every non-empty line is
+// a JSON value that becomes one log record. Lines that are not valid JSON are
+// skipped and counted, so a single corrupt line does not fail the response.
+func (r *logsReceiver) parseNDJSONLogs(ctx context.Context, body []byte, target *targetConfig, pollTime time.Time, logs plog.Logs, marks *recordMarks) (plog.Logs, error) {
+	scopeLogs := appendTargetScopeLogs(logs, target)
+
+	skipped := 0
+	for _, line := range bytes.Split(body, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		var record interface{}
+		if err := unmarshalJSON(line, &record); err != nil {
+			skipped++
+			continue
+		}
+
+		r.addLogRecord(scopeLogs, record, nil, pollTime, target, marks)
+	}
+
+	if skipped > 0 {
+		r.telemetry.recordSkippedLines(ctx, target, skipped)
+		r.logger.Warn("Skipped malformed NDJSON lines",
+			zap.String("endpoint", target.Endpoint),
+			zap.Int("skipped_lines", skipped))
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestLogsReceiver_NDJSON(t *testing.T) {
+	const body = `{"level":"error","user":{"email":"a@example.com"},"msg":"first"}
+
+{"level":"warn","user":{"email":"b@example.com"},"msg":"second"}
+{"level":"info","msg":
+not json at all
+{"level":"debug","user":{"email":"c@example.com"},"msg":"third"}
+`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	tel := componenttest.NewTelemetry()
+	defer func() { require.NoError(t, tel.Shutdown(context.Background())) }()
+	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
+	settings.TelemetrySettings = tel.NewTelemetrySettings()
+
+	target := &targetConfig{
+		ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL},
+		Labels:       map[string]string{"email": "user.email"},
+		Severity:     &severityConfig{Field: "level"},
+	}
+	require.NoError(t, target.Validate())
+
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{Targets: []*targetConfig{target}}, settings, sink)
+	r.telemetry, _ = newReceiverTelemetry(r)
+	defer func() { require.NoError(t, r.telemetry.shutdown()) }()
+	require.NoError(t, r.pollTarget(context.Background(), target))
+
+	logs := sink.AllLogs()
+	require.Len(t, logs, 1)
+	records := logs[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 3, records.Len())
+
+	wantEmails := []string{"a@example.com", "b@example.com", "c@example.com"}
+	wantSeverities := []string{"error", "warn", "debug"}
+	for i := 0; i < records.Len(); i++ {
+		record := records.At(i)
+		email, _ := record.Attributes().Get("email")
+		assert.Equal(t, wantEmails[i], email.Str())
+		assert.Equal(t, wantSeverities[i], record.SeverityText())
+
+		msg, ok := record.Body().Map().Get("msg")
+		require.True(t, ok)
+		assert.NotEmpty(t, msg.Str())
+	}
+
+	assert.Equal(t, int64(2), sumValue(t, tel, "otelcol_logsreceiver_target_skipped_lines", attribute.String(targetAttribute, srv.URL)))
+}
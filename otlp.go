@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// isOTLPJSON reports whether a decoded JSON document is an OTLP/JSON logs
+// request, which has a resourceLogs array at the top level.
+func isOTLPJSON(doc interface{}) bool {
+	object, ok := doc.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, ok = object["resourceLogs"].([]interface{})
+	return ok
+}
+
+// hasOTLPJSONPrefix reports whether a body starts with an OTLP/JSON logs
+// request, i.e. an object whose first field is a resourceLogs array. Only the
+// start of the body is needed, so this also holds for several requests one
+// after another.
+func hasOTLPJSONPrefix(prefix []byte) bool {
+	dec := json.NewDecoder(bytes.NewReader(bytes.TrimLeft(prefix, " \t\r\n\ufeff")))
+	for _, want := range []json.Token{json.Delim('{'), "resourceLogs", json.Delim('[')} {
+		if token, err := dec.Token(); err != nil || token != want {
+			return false
+		}
+	}
+	return true
+}
+
+// parseOTLPLogs unmarshals OTLP logs, which keep their resource, scope and
+// timestamps. Records without an observed timestamp are stamped with
+// pollTime. An OTLP/JSON body may hold several requests one after another,
+// as written by the file exporter.
+func parseOTLPLogs(body []byte, format string, pollTime time.Time) (plog.Logs, error) {
+	var logs plog.Logs
+
+	if format == formatOTLPProto {
+		var err error
+		unmarshaler := &plog.ProtoUnmarshaler{}
+		if logs, err = unmarshaler.UnmarshalLogs(body); err != nil {
+			return plog.Logs{}, fmt.Errorf("failed to unmarshal OTLP logs: %w", err)
+		}
+	} else {
+		logs = plog.NewLogs()
+		unmarshaler := &plog.JSONUnmarshaler{}
+		dec := json.NewDecoder(bytes.NewReader(body))
+		for {
+			var request json.RawMessage
+			if err := dec.Decode(&request); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return plog.Logs{}, fmt.Errorf("failed to unmarshal OTLP logs: %w", err)
+			}
+
+			part, err := unmarshaler.UnmarshalLogs(request)
+			if err != nil {
+				return plog.Logs{}, fmt.Errorf("failed to unmarshal OTLP logs: %w", err)
+			}
+			part.ResourceLogs().MoveAndAppendTo(logs.ResourceLogs())
+		}
+	}
+
+	observed := pcommon.NewTimestampFromTime(pollTime)
+	resourceLogs := logs.ResourceLogs()
+	for i := 0; i < resourceLogs.Len(); i++ {
+		scopeLogs := resourceLogs.At(i).ScopeLogs()
+		for j := 0; j < scopeLogs.Len(); j++ {
+			records := scopeLogs.At(j).LogRecords()
+			for k := 0; k < records.Len(); k++ {
+				if records.At(k).ObservedTimestamp() == 0 {
+					records.At(k).SetObservedTimestamp(observed)
+				}
+			}
+		}
+	}
+
+	return logs, nil
+}
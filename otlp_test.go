@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+// otlpTestLogs builds OTLP logs with a resource, a scope and a record carrying its own timestamps.
+func otlpTestLogs(service string) plog.Logs {
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	resourceLogs.Resource().Attributes().PutStr("service.name", service)
+	scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
+	scopeLogs.Scope().SetName("sidecar")
+	record := scopeLogs.LogRecords().AppendEmpty()
+	record.SetTimestamp(pcommon.NewTimestampFromTime(time.Date(2025, 10, 15, 10, 0, 0, 0, time.UTC)))
+	record.SetSeverityNumber(plog.SeverityNumberWarn)
+	record.Body().SetStr("disk almost full")
+	record.Attributes().PutInt("disk.free", 3)
+	return logs
+}
+
+func TestLogsReceiver_OTLP(t *testing.T) {
+	proto, err := (&plog.ProtoMarshaler{}).MarshalLogs(otlpTestLogs("checkout"))
+	require.NoError(t, err)
+	first, err := (&plog.JSONMarshaler{}).MarshalLogs(otlpTestLogs("checkout"))
+	require.NoError(t, err)
+	second, err := (&plog.JSONMarshaler{}).MarshalLogs(otlpTestLogs("billing"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		contentType  string
+		body         []byte
+		format       string
+		wantServices []string
+	}{
+		{
+			name:         "protobuf",
+			contentType:  "application/x-protobuf",
+			body:         proto,
+			wantServices: []string{"checkout"},
+		},
+		{
+			name:         "json detected from the document",
+			contentType:  "application/json",
+			body:         first,
+			wantServices: []string{"checkout"},
+		},
+		{
+			name:         "json lines with explicit format",
+			contentType:  "application/x-ndjson",
+			body:         append(append(append([]byte{}, first...), '\n'), second...),
+			format:       "otlp_json",
+			wantServices: []string{"checkout", "billing"},
+		},
+		{
+			name:         "json served as text",
+			contentType:  "text/plain; charset=utf-8",
+			body:         first,
+			wantServices: []string{"checkout"},
+		},
+		{
+			name:         "json lines detected from the first line",
+			contentType:  "application/x-ndjson",
+			body:         append(append(append([]byte{}, first...), '\n'), second...),
+			wantServices: []string{"checkout", "billing"},
+		},
+		{
+			name:         "json lines sniffed",
+			body:         append(append(append([]byte{}, first...), '\n'), second...),
+			wantServices: []string{"checkout", "billing"},
+		},
+		{
+			name:         "protobuf with explicit format",
+			contentType:  "application/octet-stream",
+			body:         proto,
+			format:       "otlp_proto",
+			wantServices: []string{"checkout"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write(tt.body)
+			}))
+			defer srv.Close()
+
+			target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}, Format: tt.format}
+			require.NoError(t, target.Validate())
+
+			sink := &testLogsSink{}
+			r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), sink)
+			require.NoError(t, r.pollTarget(context.Background(), target))
+
+			logs := sink.AllLogs()
+			require.Len(t, logs, 1)
+			resourceLogs := logs[0].ResourceLogs()
+			require.Equal(t, len(tt.wantServices), resourceLogs.Len())
+
+			for i, service := range tt.wantServices {
+				assert.Equal(t, map[string]interface{}{"service.name": service}, resourceLogs.At(i).Resource().Attributes().AsRaw())
+				scopeLogs := resourceLogs.At(i).ScopeLogs().At(0)
+				assert.Equal(t, "sidecar", scopeLogs.Scope().Name())
+
+				record := scopeLogs.LogRecords().At(0)
+				assert.Equal(t, time.Date(2025, 10, 15, 10, 0, 0, 0, time.UTC), record.Timestamp().AsTime())
+				assert.NotZero(t, record.ObservedTimestamp())
+				assert.Equal(t, plog.SeverityNumberWarn, record.SeverityNumber())
+				assert.Equal(t, "disk almost full", record.Body().Str())
+				assert.Equal(t, map[string]interface{}{"disk.free": int64(3)}, record.Attributes().AsRaw())
+			}
+		})
+	}
+}
+
+func TestLogsReceiver_OTLPNotDetected(t *testing.T) {
+	body, err := (&plog.JSONMarshaler{}).MarshalLogs(otlpTestLogs("checkout"))
+	require.NoError(t, err)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	defer srv.Close()
+
+	// an explicit json format treats the document as an ordinary record
+	target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}, Format: "json"}
+	require.NoError(t, target.Validate())
+
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), sink)
+	require.NoError(t, r.pollTarget(context.Background(), target))
+
+	resourceLogs := sink.AllLogs()[0].ResourceLogs()
+	require.Equal(t, 1, resourceLogs.Len())
+	service, _ := resourceLogs.At(0).Resource().Attributes().Get("service.name")
+	assert.Equal(t, "logs-receiver", service.Str())
+	_, ok := resourceLogs.At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Map().Get("resourceLogs")
+	assert.True(t, ok)
+}
+
+func TestLogsReceiver_OTLPInvalid(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		_, _ = w.Write([]byte{0xff, 0xff, 0xff})
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}}
+	require.NoError(t, target.Validate())
+
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), &testLogsSink{})
+	require.ErrorContains(t, r.pollTarget(context.Background(), target), "failed to unmarshal OTLP logs")
+}
+
+func TestHasOTLPJSONPrefix(t *testing.T) {
+	body, err := (&plog.JSONMarshaler{}).MarshalLogs(otlpTestLogs("checkout"))
+	require.NoError(t, err)
+
+	assert.True(t, hasOTLPJSONPrefix(body))
+	assert.True(t, hasOTLPJSONPrefix(body[:20]))
+	assert.True(t, hasOTLPJSONPrefix([]byte("\n { \"resourceLogs\" : [")))
+	assert.False(t, hasOTLPJSONPrefix([]byte(`{"resourceLogs":{}}`)))
+	assert.False(t, hasOTLPJSONPrefix([]byte(`{"items":[],"resourceLogs":[]}`)))
+	assert.False(t, hasOTLPJSONPrefix([]byte(`[{"resourceLogs":[]}]`)))
+	assert.False(t, hasOTLPJSONPrefix([]byte("resourceLogs")))
+}
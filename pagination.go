@@ -0,0 +1,261 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// paginator tracks the position within a paginated poll. A nil paginator
+// fetches a single page.
+type paginator struct {
+	cfg     *paginationConfig
+	extract func(path string, raw interface{}) interface{}
+
+	fetched   int
+	truncated bool
+	nextURL   *url.URL
+	cursor    string
+	sent      string
+	offset    int
+	page      int
+}
+
+// newPaginator creates a paginator for the config, or nil when pagination is disabled.
+// In cursor mode the first request resumes from cursor when it is not empty.
+func newPaginator(cfg *paginationConfig, extract func(path string, raw interface{}) interface{}, cursor string) *paginator {
+	if cfg == nil {
+		return nil
+	}
+
+	p := &paginator{
+		cfg:     cfg,
+		extract: extract,
+		page:    cfg.StartPage,
+	}
+	if cfg.Mode == paginationModeCursor {
+		p.cursor = cursor
+	}
+
+	return p
+}
+
+// currentCursor returns the latest cursor, or an empty string outside cursor mode.
+func (p *paginator) currentCursor() string {
+	if p == nil {
+		return ""
+	}
+
+	return p.cursor
+}
+
+// cursorFetched reports whether the page of the current cursor has already
+// been fetched, which is the case when the last page returned no new cursor.
+func (p *paginator) cursorFetched() bool {
+	return p != nil && p.cursor != "" && p.cursor == p.sent
+}
+
+// needsBody reports whether the decoded response body is required to find the next page.
+func (p *paginator) needsBody() bool {
+	if p == nil {
+		return false
+	}
+
+	return p.cfg.Mode == paginationModeNextURL || p.cfg.Mode == paginationModeCursor || p.cfg.HasMorePath != ""
+}
+
+// bodyPaths returns the paths of the response body values used to find the next page.
+func (p *paginator) bodyPaths() []string {
+	if p == nil {
+		return nil
+	}
+
+	var paths []string
+	switch p.cfg.Mode {
+	case paginationModeNextURL:
+		paths = append(paths, p.cfg.NextURLPath)
+	case paginationModeCursor:
+		paths = append(paths, p.cfg.CursorPath)
+	}
+	if p.cfg.HasMorePath != "" {
+		paths = append(paths, p.cfg.HasMorePath)
+	}
+
+	return paths
+}
+
+// apply points the request at the current page.
+func (p *paginator) apply(req *http.Request) {
+	if p == nil {
+		return
+	}
+
+	if p.nextURL != nil {
+		req.URL = p.nextURL
+		req.Host = p.nextURL.Host
+		return
+	}
+
+	query := req.URL.Query()
+	switch p.cfg.Mode {
+	case paginationModeCursor:
+		if p.cursor == "" {
+			return
+		}
+		query.Set(p.cfg.CursorParam, p.cursor)
+		p.sent = p.cursor
+	case paginationModeOffset:
+		query.Set(p.cfg.OffsetParam, strconv.Itoa(p.offset))
+		if p.cfg.Limit > 0 {
+			query.Set(p.cfg.LimitParam, strconv.Itoa(p.cfg.Limit))
+		}
+	case paginationModePage:
+		query.Set(p.cfg.PageParam, strconv.Itoa(p.page))
+		if p.cfg.Limit > 0 {
+			query.Set(p.cfg.LimitParam, strconv.Itoa(p.cfg.Limit))
+		}
+	default:
+		return
+	}
+	req.URL.RawQuery = query.Encode()
+}
+
+// advance records a fetched page and reports whether another page should be fetched.
+// doc is the decoded JSON body, or nil when needsBody is false.
+func (p *paginator) advance(req *http.Request, resp *http.Response, doc interface{}, records int) (bool, error) {
+	if p == nil {
+		return false, nil
+	}
+
+	p.fetched++
+	more, err := p.next(req, resp, doc, records)
+	if err != nil || !more {
+		return false, err
+	}
+
+	if p.fetched >= p.cfg.MaxPages {
+		p.truncated = true
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// next moves to the page following the fetched one and reports whether there is one.
+func (p *paginator) next(req *http.Request, resp *http.Response, doc interface{}, records int) (bool, error) {
+	if p.cfg.HasMorePath != "" {
+		if hasMore := p.extract(p.cfg.HasMorePath, doc); fmt.Sprintf("%v", hasMore) != "true" {
+			return false, nil
+		}
+	}
+
+	switch p.cfg.Mode {
+	case paginationModeNextURL:
+		next, ok := p.extract(p.cfg.NextURLPath, doc).(string)
+		if !ok || next == "" {
+			return false, nil
+		}
+		return p.setNextURL(req.URL, next)
+
+	case paginationModeLinkHeader:
+		next := nextLink(resp.Header.Values("Link"))
+		if next == "" {
+			return false, nil
+		}
+		return p.setNextURL(req.URL, next)
+
+	case paginationModeCursor:
+		raw := p.extract(p.cfg.CursorPath, doc)
+		if raw == nil {
+			return false, nil
+		}
+		cursor := fmt.Sprintf("%v", raw)
+		if cursor == "" || cursor == p.cursor {
+			return false, nil
+		}
+		p.cursor = cursor
+		return true, nil
+
+	case paginationModeOffset:
+		if records == 0 || (p.cfg.Limit > 0 && records < p.cfg.Limit) {
+			return false, nil
+		}
+		if p.cfg.Limit > 0 {
+			p.offset += p.cfg.Limit
+		} else {
+			p.offset += records
+		}
+		return true, nil
+
+	case paginationModePage:
+		if records == 0 || (p.cfg.Limit > 0 && records < p.cfg.Limit) {
+			return false, nil
+		}
+		p.page++
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// stoppedAtLimit reports whether max_pages ended the poll while more pages were available.
+func (p *paginator) stoppedAtLimit() bool {
+	return p != nil && p.truncated
+}
+
+// setNextURL resolves next against the current URL and stops on loops. A
+// next URL on another scheme or host is refused, as the target's headers and
+// auth would be sent along with the request.
+func (p *paginator) setNextURL(current *url.URL, next string) (bool, error) {
+	ref, err := url.Parse(next)
+	if err != nil {
+		return false, fmt.Errorf("invalid next page URL %q: %w", next, err)
+	}
+
+	resolved := current.ResolveReference(ref)
+	if resolved.String() == current.String() {
+		return false, nil
+	}
+	if !strings.EqualFold(resolved.Scheme, current.Scheme) || !strings.EqualFold(resolved.Host, current.Host) {
+		return false, fmt.Errorf("next page URL %q is not on the target's origin %s://%s", next, current.Scheme, current.Host)
+	}
+
+	p.nextURL = resolved
+	return true, nil
+}
+
+// nextLink returns the target of the rel="next" link in RFC 5988 Link header values.
+func nextLink(values []string) string {
+	for _, value := range values {
+		for _, link := range strings.Split(value, ",") {
+			segments := strings.Split(link, ";")
+			if len(segments) < 2 {
+				continue
+			}
+
+			target := strings.TrimSpace(segments[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			for _, param := range segments[1:] {
+				key, val, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(val), `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return strings.Trim(target, "<>")
+					}
+				}
+			}
+		}
+	}
+
+	return ""
+}
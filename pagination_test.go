@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNextLink(t *testing.T) {
+	tests := map[string]struct {
+		values []string
+		want   string
+	}{
+		"github style": {
+			values: []string{`<https://api.example.com/items?page=2>; rel="next", <https://api.example.com/items?page=5>; rel="last"`},
+			want:   "https://api.example.com/items?page=2",
+		},
+		"multiple rels": {
+			values: []string{`</items?page=1>; rel="prev first"`, `</items?page=3>; rel="next last"`},
+			want:   "/items?page=3",
+		},
+		"unquoted rel": {
+			values: []string{`</items?page=3>; title="x"; rel=next`},
+			want:   "/items?page=3",
+		},
+		"no next": {
+			values: []string{`</items?page=1>; rel="prev"`},
+			want:   "",
+		},
+		"no header": {
+			want: "",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nextLink(tt.values))
+		})
+	}
+}
+
+// pagedItems returns n JSON items starting at id start.
+func pagedItems(start, n int) string {
+	items := ""
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			items += ","
+		}
+		items += fmt.Sprintf(`{"id":%d}`, start+i)
+	}
+	return "[" + items + "]"
+}
+
+func TestLogsReceiver_Pagination(t *testing.T) {
+	tests := []struct {
+		name        string
+		pagination  paginationConfig
+		recordsPath string
+		handler     http.HandlerFunc
+		wantRecords int
+		wantPages   int
+		wantLimited bool
+	}{
+		{
+			name:        "next url from body",
+			pagination:  paginationConfig{Mode: paginationModeNextURL, NextURLPath: "next"},
+			recordsPath: "items",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				page, _ := strconv.Atoi(r.URL.Query().Get("p"))
+				next := ""
+				if page < 2 {
+					next = fmt.Sprintf("?p=%d", page+1)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(w, `{"items":%s,"next":%q}`, pagedItems(page*2, 2), next)
+			},
+			wantRecords: 6,
+			wantPages:   3,
+		},
+		{
+			name:       "link header",
+			pagination: paginationConfig{Mode: paginationModeLinkHeader},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				if page < 1 {
+					w.Header().Set("Link", fmt.Sprintf(`<http://%s/?page=%d>; rel="next"`, r.Host, page+1))
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(pagedItems(page*3, 3)))
+			},
+			wantRecords: 6,
+			wantPages:   2,
+		},
+		{
+			name:        "cursor",
+			pagination:  paginationConfig{Mode: paginationModeCursor, CursorPath: "meta.cursor", CursorParam: "after"},
+			recordsPath: "data",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				cursors := map[string]string{"": "c1", "c1": "c2", "c2": ""}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(w, `{"data":%s,"meta":{"cursor":%q}}`, pagedItems(0, 1), cursors[r.URL.Query().Get("after")])
+			},
+			wantRecords: 3,
+			wantPages:   3,
+		},
+		{
+			name:       "offset stops on short page",
+			pagination: paginationConfig{Mode: paginationModeOffset, Limit: 2},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+				n := 2
+				if offset >= 4 {
+					n = 1
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(pagedItems(offset, n)))
+			},
+			wantRecords: 5,
+			wantPages:   3,
+		},
+		{
+			name:        "page number with has_more",
+			pagination:  paginationConfig{Mode: paginationModePage, HasMorePath: "has_more"},
+			recordsPath: "items",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(w, `{"items":%s,"has_more":%v}`, pagedItems(page, 1), page < 4)
+			},
+			wantRecords: 4,
+			wantPages:   4,
+		},
+		{
+			name:       "max pages cap",
+			pagination: paginationConfig{Mode: paginationModePage, MaxPages: 3},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(pagedItems(0, 1)))
+			},
+			wantRecords: 3,
+			wantPages:   3,
+			wantLimited: true,
+		},
+		{
+			name:        "max pages matching the last page",
+			pagination:  paginationConfig{Mode: paginationModePage, MaxPages: 3, HasMorePath: "has_more"},
+			recordsPath: "items",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(w, `{"items":%s,"has_more":%v}`, pagedItems(page, 1), page < 3)
+			},
+			wantRecords: 3,
+			wantPages:   3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				tt.handler(w, r)
+			}))
+			defer srv.Close()
+
+			core, observed := observer.New(zap.WarnLevel)
+			settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
+			settings.Logger = zap.New(core)
+			sink := &testLogsSink{}
+			r := newLogsReceiver(&Config{}, settings, sink)
+			pagination := tt.pagination
+			target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}, RecordsPath: tt.recordsPath, Pagination: &pagination}
+			require.NoError(t, target.Validate())
+
+			require.NoError(t, r.pollTarget(context.Background(), target))
+
+			records := 0
+			for _, l := range sink.AllLogs() {
+				records += l.LogRecordCount()
+			}
+			assert.Equal(t, tt.wantRecords, records)
+			assert.Equal(t, tt.wantPages, int(requests.Load()))
+			assert.Equal(t, tt.wantLimited, observed.FilterMessage("Stopped pagination after reaching max_pages").Len() == 1)
+		})
+	}
+}
+
+func TestLogsReceiver_PaginationCrossOrigin(t *testing.T) {
+	var foreignRequests atomic.Int32
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		foreignRequests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[]}`))
+	}))
+	defer foreign.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"items":%s,"next":%q}`, pagedItems(0, 2), foreign.URL+"/steal")
+	}))
+	defer srv.Close()
+
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), sink)
+	target := &targetConfig{
+		ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL, Headers: map[string]configopaque.String{"Authorization": "Bearer secret"}},
+		RecordsPath:  "items",
+		Pagination:   &paginationConfig{Mode: paginationModeNextURL, NextURLPath: "next"},
+	}
+	require.NoError(t, target.Validate())
+
+	// the page already read is kept, but the target's credentials never reach the other host
+	require.ErrorContains(t, r.pollTarget(context.Background(), target), "is not on the target's origin")
+	assert.Equal(t, 2, recordCount(sink))
+	assert.Zero(t, foreignRequests.Load())
+}
@@ -4,8 +4,11 @@
 package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -21,32 +24,80 @@ import (
 	"go.uber.org/zap"
 )
 
-// logsReceiver implements the logs receiver interface.
+// logsReceiver implements the logs receiver interface. The same instance
+// also serves a metrics pipeline with the health of its polls.
 type logsReceiver struct {
 	config   *Config
 	settings receiver.Settings
 	consumer consumer.Logs
 	logger   *zap.Logger
+	state    *stateStore
+	host     component.Host
 	cancel   context.CancelFunc
-	done     chan struct{}
+	wg       sync.WaitGroup
+
+	clientsMu sync.Mutex
+	clients   map[*targetConfig]*http.Client
+
+	breakers  map[*targetConfig]*circuitBreaker
+	telemetry *receiverTelemetry
+	status    *statusReporter
+
+	// Consumer of the poll health metrics, set when the receiver is also used in a metrics pipeline
+	metricsConsumer consumer.Metrics
 }
 
 // newLogsReceiver creates a new logs receiver.
 func newLogsReceiver(config *Config, settings receiver.Settings, consumer consumer.Logs) *logsReceiver {
+	breakers := map[*targetConfig]*circuitBreaker{}
+	for _, target := range config.Targets {
+		if target.CircuitBreaker != nil {
+			breakers[target] = newCircuitBreaker(target.CircuitBreaker)
+		}
+	}
+
 	return &logsReceiver{
 		config:   config,
 		settings: settings,
 		consumer: consumer,
 		logger:   settings.Logger,
-		done:     make(chan struct{}),
+		state:    newStateStore(),
+		clients:  map[*targetConfig]*http.Client{},
+		breakers: breakers,
 	}
 }
 
 // Start starts the logs receiver.
-func (r *logsReceiver) Start(ctx context.Context, _ component.Host) error {
+func (r *logsReceiver) Start(ctx context.Context, host component.Host) error {
+	r.host = host
+
+	for _, target := range r.config.Targets {
+		if _, err := r.getClient(ctx, target); err != nil {
+			return fmt.Errorf("failed to create HTTP client for %s: %w", target.Endpoint, err)
+		}
+	}
+
+	if r.config.StorageID != nil {
+		client, err := getStorageClient(ctx, host, *r.config.StorageID, r.settings.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get storage client: %w", err)
+		}
+		r.state.setClient(client)
+	}
+
+	telemetry, err := newReceiverTelemetry(r)
+	if err != nil {
+		return fmt.Errorf("failed to create telemetry: %w", err)
+	}
+	r.telemetry = telemetry
+	r.status = newStatusReporter(host, r.config.AuthFailureThreshold)
+
 	ctx, r.cancel = context.WithCancel(ctx)
 
-	go r.poll(ctx)
+	for _, target := range r.config.Targets {
+		r.wg.Add(1)
+		go r.schedule(ctx, target)
+	}
 
 	r.logger.Info("Logs receiver started",
 		zap.Duration("collection_interval", r.config.CollectionInterval),
@@ -56,119 +107,408 @@ func (r *logsReceiver) Start(ctx context.Context, _ component.Host) error {
 }
 
 // Shutdown stops the logs receiver.
-func (r *logsReceiver) Shutdown(_ context.Context) error {
+func (r *logsReceiver) Shutdown(ctx context.Context) error {
 	if r.cancel != nil {
 		r.cancel()
 	}
 
-	<-r.done
+	r.wg.Wait()
+
+	if err := r.telemetry.shutdown(); err != nil {
+		r.logger.Warn("Failed to unregister telemetry", zap.Error(err))
+	}
+
+	r.clientsMu.Lock()
+	for _, client := range r.clients {
+		client.CloseIdleConnections()
+	}
+	r.clientsMu.Unlock()
+
+	if err := r.state.close(ctx); err != nil {
+		return fmt.Errorf("failed to close storage client: %w", err)
+	}
+
 	r.logger.Info("Logs receiver stopped")
 	return nil
 }
 
-// poll continuously polls the configured endpoints for logs.
-func (r *logsReceiver) poll(ctx context.Context) {
-	defer close(r.done)
+// schedule polls a single target on its own interval until the context is
+// cancelled. Polls of the target run one at a time, so a slow poll only
+// delays the target's own next run and never another target's.
+func (r *logsReceiver) schedule(ctx context.Context, target *targetConfig) {
+	defer r.wg.Done()
 
-	ticker := time.NewTicker(r.config.CollectionInterval)
-	defer ticker.Stop()
+	if target.InitialDelay > 0 {
+		timer := time.NewTimer(target.InitialDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
 
-	// Poll immediately on start
-	r.pollTargets(ctx)
+	ticker := time.NewTicker(r.interval(target))
+	defer ticker.Stop()
 
+	breaker := r.breakers[target]
 	for {
+		r.scheduledPoll(ctx, target, breaker)
+
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			r.pollTargets(ctx)
 		}
 	}
 }
 
-// pollTargets polls all configured targets.
-func (r *logsReceiver) pollTargets(ctx context.Context) {
-	var wg sync.WaitGroup
+// scheduledPoll polls the target unless its circuit breaker is open. Failures
+// are logged individually only while the breaker is closed; once it trips,
+// only state transitions are logged.
+func (r *logsReceiver) scheduledPoll(ctx context.Context, target *targetConfig, breaker *circuitBreaker) {
+	allowed, from, to := breaker.allow(time.Now())
+	if !allowed {
+		r.consumePollMetrics(ctx, target, time.Now(), nil, errCircuitOpen)
+		return
+	}
+	if from != to {
+		r.logger.Info("Probing target after circuit breaker timeout",
+			zap.String("endpoint", target.Endpoint))
+	}
 
-	for _, target := range r.config.Targets {
-		wg.Add(1)
-		go func(target *targetConfig) {
-			defer wg.Done()
-			if err := r.pollTarget(ctx, target); err != nil {
-				r.logger.Error("Failed to poll target",
-					zap.String("endpoint", target.Endpoint),
-					zap.Error(err))
-			}
-		}(target)
+	err := r.pollTarget(ctx, target)
+	if ctx.Err() != nil {
+		return
 	}
 
-	wg.Wait()
+	r.status.record(target, err)
+
+	from, to = breaker.record(err, time.Now())
+	switch {
+	case to == circuitOpen && from != circuitOpen:
+		r.logger.Warn("Circuit breaker opened, backing off from target",
+			zap.String("endpoint", target.Endpoint),
+			zap.String("from", from.String()),
+			zap.Error(err))
+	case to == circuitClosed && from != circuitClosed:
+		r.logger.Info("Circuit breaker closed, target recovered",
+			zap.String("endpoint", target.Endpoint))
+	case err != nil && to == circuitClosed:
+		r.logger.Error("Failed to poll target",
+			zap.String("endpoint", target.Endpoint),
+			zap.Error(err))
+	}
+}
+
+// interval returns the collection interval of the target, falling back to
+// the receiver's interval when the target does not override it.
+func (r *logsReceiver) interval(target *targetConfig) time.Duration {
+	if target.CollectionInterval > 0 {
+		return target.CollectionInterval
+	}
+	return r.config.CollectionInterval
 }
 
-// pollTarget polls a single target endpoint.
+// pollTarget polls a single target endpoint and records the poll in the target state.
 func (r *logsReceiver) pollTarget(ctx context.Context, target *targetConfig) error {
-	req, err := r.createRequest(ctx, target)
+	pollTime := time.Now()
+
+	key := stateKey(target)
+	state, err := r.state.get(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	var stats pollStats
+	err = r.pollPages(ctx, target, pollTime, &state, &stats)
+
+	state.LastPollTime = pollTime
+	if err == nil {
+		state.LastSuccessTime = pollTime
+	}
+	if putErr := r.state.put(ctx, key, state); putErr != nil {
+		r.logger.Warn("Failed to checkpoint target state",
+			zap.String("endpoint", target.Endpoint),
+			zap.Error(putErr))
+	}
+
+	r.consumePollMetrics(ctx, target, pollTime, &stats, err)
+
+	return err
+}
+
+// consumePollMetrics passes the health metrics of a poll to the metrics
+// pipeline, when the receiver is used in one.
+func (r *logsReceiver) consumePollMetrics(ctx context.Context, target *targetConfig, pollTime time.Time, stats *pollStats, pollErr error) {
+	if r.metricsConsumer == nil {
+		return
+	}
+
+	metrics := buildPollMetrics(target, pollTime, time.Now(), stats, pollErr)
+	if err := r.metricsConsumer.ConsumeMetrics(ctx, metrics); err != nil {
+		r.logger.Warn("Failed to consume poll metrics",
+			zap.String("endpoint", target.Endpoint),
+			zap.Error(err))
+	}
+}
+
+// pollPages fetches all pages of a poll, following pagination when configured.
+func (r *logsReceiver) pollPages(ctx context.Context, target *targetConfig, pollTime time.Time, state *targetState, stats *pollStats) error {
+	vars := newTemplateVars(pollTime, *state, r.interval(target))
+	pager := newPaginator(target.Pagination, r.extractValueByPath, state.LastCursor)
+	seen := newSeenFilter(target, *state, r.extractValueByPath)
+
+	client, err := r.getClient(ctx, target)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create HTTP client: %w", err)
 	}
 
-	client := &http.Client{
-		Timeout: 30 * time.Second,
+	for {
+		req, err := r.createRequest(ctx, target, vars)
+		if err != nil {
+			return &requestError{err: err}
+		}
+		pager.apply(req)
+
+		more, err := r.fetchPage(ctx, client, req, target, pollTime, pager, seen, state, stats)
+		if err != nil {
+			return err
+		}
+		seen = nil
+
+		if !more {
+			break
+		}
 	}
 
-	resp, err := client.Do(req)
+	if pager.stoppedAtLimit() {
+		r.logger.Warn("Stopped pagination after reaching max_pages",
+			zap.String("endpoint", target.Endpoint),
+			zap.Int("max_pages", target.Pagination.MaxPages))
+	}
+
+	return nil
+}
+
+// getClient returns the long-lived HTTP client of the target, creating it on first use.
+func (r *logsReceiver) getClient(ctx context.Context, target *targetConfig) (*http.Client, error) {
+	r.clientsMu.Lock()
+	defer r.clientsMu.Unlock()
+
+	if client, ok := r.clients[target]; ok {
+		return client, nil
+	}
+
+	// Authenticators are extensions, which are only known once the receiver is started
+	if target.Auth.HasValue() && r.host == nil {
+		return nil, errors.New("auth extension cannot be resolved before the receiver is started")
+	}
+
+	// Headers are templated, so they are rendered per request instead of by the client
+	clientConfig := target.ClientConfig
+	clientConfig.Headers = nil
+
+	client, err := clientConfig.ToClient(ctx, r.host, r.settings.TelemetrySettings)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return nil, err
+	}
+
+	r.clients[target] = client
+	return client, nil
+}
+
+// fetchPage executes a single page request, consumes its log records and
+// checkpoints the target state. It reports whether another page should be fetched.
+func (r *logsReceiver) fetchPage(ctx context.Context, client *http.Client, req *http.Request, target *targetConfig, pollTime time.Time, pager *paginator, seen *seenFilter, state *targetState, stats *pollStats) (bool, error) {
+	resp, err := r.doRequest(ctx, client, req, target)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
+	stats.statusCode = resp.StatusCode
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("HTTP error: %d %s", resp.StatusCode, resp.Status)
+		return false, &httpStatusError{statusCode: resp.StatusCode, status: resp.Status}
+	}
+
+	body := bufio.NewReaderSize(resp.Body, sniffLen)
+	format := responseFormat(target, resp, body)
+
+	var result page
+	if target.Streaming != nil && format == formatJSON {
+		result, err = r.streamPage(ctx, resp, body, target, pollTime, pager, seen)
+	} else {
+		result, err = r.readPage(ctx, resp, body, format, target, pollTime, pager, seen)
+	}
+	stats.records += result.records
+	stats.bytes += result.bytes
+	if err != nil {
+		return false, err
+	}
+	r.telemetry.recordPage(ctx, target, result.bytes, result.records)
+
+	more, err := pager.advance(req, resp, result.doc, result.records)
+	if err != nil {
+		return false, err
+	}
+
+	if result.latest.After(state.LastTimestamp) {
+		state.LastTimestamp = result.latest
+	}
+	if cursor := pager.currentCursor(); cursor != "" {
+		state.LastCursor, state.LastCursorFetched = cursor, pager.cursorFetched()
+	}
+	if result.lastID != "" {
+		state.LastID = result.lastID
+	}
+	if err := r.state.put(ctx, stateKey(target), *state); err != nil {
+		r.logger.Warn("Failed to checkpoint target state",
+			zap.String("endpoint", target.Endpoint),
+			zap.Error(err))
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	return more, nil
+}
+
+// page is the outcome of reading a single response.
+type page struct {
+	// Decoded body, or only the values needed from it when streaming. Nil
+	// when neither pagination nor id_field needs it.
+	doc interface{}
+
+	// Log records produced and body bytes read
+	records int
+	bytes   int
+
+	// Newest record timestamp and ID of the last record
+	latest time.Time
+	lastID string
+}
+
+// readPage reads the whole response body, parses it in the given format and
+// consumes its log records that were not emitted before.
+func (r *logsReceiver) readPage(ctx context.Context, resp *http.Response, reader io.Reader, format string, target *targetConfig, pollTime time.Time, pager *paginator, seen *seenFilter) (page, error) {
+	body, err := io.ReadAll(reader)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return page{}, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	logs, err := r.parseLogs(resp, body, target)
+	// A JSON body is decoded once, for its records as well as for pagination and id_field
+	var doc interface{}
+	var logs plog.Logs
+	var marks recordMarks
+	if format == formatJSON {
+		if doc, err = decodeJSONBody(body); err == nil {
+			logs, err = r.parseJSONDoc(doc, body, target, pollTime, plog.NewLogs(), &marks)
+		}
+	} else {
+		logs, err = r.parseLogs(ctx, format, body, target, pollTime, &marks)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to parse logs: %w", err)
+		r.telemetry.recordParseError(ctx, target)
+		return page{bytes: len(body)}, fmt.Errorf("failed to parse logs: %w", err)
+	}
+	seen.filter(logs)
+
+	result := page{
+		doc:     doc,
+		records: logs.LogRecordCount(),
+		bytes:   len(body),
+		latest:  marks.latest,
+		lastID:  marks.lastID,
+	}
+	if err := r.consumeLogs(ctx, resp, target, format, logs); err != nil {
+		return result, err
 	}
 
-	if logs.LogRecordCount() > 0 {
-		if err := r.consumer.ConsumeLogs(ctx, logs); err != nil {
-			return fmt.Errorf("failed to consume logs: %w", err)
+	if format != formatJSON && pager.needsBody() {
+		if err := unmarshalJSON(body, &result.doc); err != nil {
+			return result, fmt.Errorf("failed to unmarshal JSON for pagination: %w", err)
 		}
+	}
 
-		r.logger.Debug("Successfully consumed logs",
-			zap.String("endpoint", target.Endpoint),
-			zap.Int("log_count", logs.LogRecordCount()))
+	return result, nil
+}
+
+// consumeLogs passes log records read from the response to the pipeline.
+func (r *logsReceiver) consumeLogs(ctx context.Context, resp *http.Response, target *targetConfig, format string, logs plog.Logs) error {
+	count := logs.LogRecordCount()
+	if count == 0 || r.consumer == nil {
+		return nil
 	}
 
+	obsCtx := r.telemetry.startLogsOp(ctx)
+	err := r.consumer.ConsumeLogs(obsCtx, logs)
+	r.telemetry.endLogsOp(obsCtx, format, count, err)
+	if err != nil {
+		return fmt.Errorf("failed to consume logs: %w", err)
+	}
+
+	r.logger.Debug("Successfully consumed logs",
+		zap.String("endpoint", target.Endpoint),
+		zap.String("url", resp.Request.URL.String()),
+		zap.Int("log_count", count))
+
 	return nil
 }
 
-// createRequest creates an HTTP request for the target.
-func (r *logsReceiver) createRequest(ctx context.Context, target *targetConfig) (*http.Request, error) {
+// recordMarks collects the checkpoint values of a page while its records are
+// parsed.
+type recordMarks struct {
+	// latest is the newest event time taken from a record; records that fall
+	// back to the poll time do not count
+	latest time.Time
+	// lastID is the id_field value of the last record
+	lastID string
+}
+
+// addTimestamp notes an event time taken from a record.
+func (m *recordMarks) addTimestamp(ts time.Time) {
+	if ts.After(m.latest) {
+		m.latest = ts
+	}
+}
+
+// createRequest creates an HTTP request for the target, rendering its
+// endpoint, body and headers with the poll variables.
+func (r *logsReceiver) createRequest(ctx context.Context, target *targetConfig, vars templateVars) (*http.Request, error) {
+	endpoint, err := renderTemplate(target.endpointTemplate, target.Endpoint, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	requestBody, err := renderTemplate(target.bodyTemplate, target.Body, vars)
+	if err != nil {
+		return nil, err
+	}
+
 	var body io.Reader
-	if target.Body != "" {
-		body = strings.NewReader(target.Body)
+	if requestBody != "" {
+		body = strings.NewReader(requestBody)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, target.Method, target.Endpoint, body)
+	req, err := http.NewRequestWithContext(ctx, target.Method, endpoint, body)
 	if err != nil {
 		return nil, err
 	}
 
 	// Set headers
 	for key, value := range target.Headers {
-		req.Header.Set(key, value)
+		headerValue, err := renderTemplate(target.headerTemplates[key], string(value), vars)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(key, "Host") {
+			req.Host = headerValue
+			continue
+		}
+		req.Header.Set(key, headerValue)
 	}
 
 	// Set default Content-Type for POST/PUT with body
-	if target.Body != "" && req.Header.Get("Content-Type") == "" {
-		if strings.HasPrefix(strings.TrimSpace(target.Body), "{") {
+	if requestBody != "" && req.Header.Get("Content-Type") == "" {
+		if strings.HasPrefix(strings.TrimSpace(requestBody), "{") {
 			req.Header.Set("Content-Type", "application/json")
 		} else {
 			req.Header.Set("Content-Type", "text/plain")
@@ -178,44 +518,130 @@ func (r *logsReceiver) createRequest(ctx context.Context, target *targetConfig)
 	return req, nil
 }
 
-// parseLogs parses the response body into log records.
-func (r *logsReceiver) parseLogs(resp *http.Response, body []byte, target *targetConfig) (plog.Logs, error) {
+// parseLogs parses the response body in the given format into log records observed at pollTime.
+func (r *logsReceiver) parseLogs(ctx context.Context, format string, body []byte, target *targetConfig, pollTime time.Time, marks *recordMarks) (plog.Logs, error) {
 	logs := plog.NewLogs()
 
-	ct := strings.ToLower(resp.Header.Get("Content-Type"))
-	switch {
-	case strings.Contains(ct, "application/json"):
-		return r.parseJSONLogs(body, target, logs)
-	case strings.Contains(ct, "text"):
-		return r.parseTextLogs(body, target, logs)
+	switch format {
+	case formatJSON:
+		return r.parseJSONLogs(body, target, pollTime, logs, marks)
+	case formatNDJSON:
+		return r.parseNDJSONLogs(ctx, body, target, pollTime, logs, marks)
+	case formatCSV, formatTSV:
+		return r.parseCSVLogs(ctx, body, format, target, pollTime, logs, marks)
+	case formatXML:
+		return r.parseXMLLogs(body, target, pollTime, logs, marks)
+	case formatSyslog:
+		return r.parseSyslogLogs(body, target, pollTime, logs)
+	case formatLogfmt:
+		return r.parseLogfmtLogs(body, target, pollTime, logs, marks)
+	case formatOTLPJSON, formatOTLPProto:
+		return parseOTLPLogs(body, format, pollTime)
 	default:
-		return r.parseTextLogs(body, target, logs)
+		return r.parseTextLogs(body, target, pollTime, logs)
 	}
 }
 
 // parseJSONLogs parses JSON formatted logs.
-func (r *logsReceiver) parseJSONLogs(body []byte, target *targetConfig, logs plog.Logs) (plog.Logs, error) {
+func (r *logsReceiver) parseJSONLogs(body []byte, target *targetConfig, pollTime time.Time, logs plog.Logs, marks *recordMarks) (plog.Logs, error) {
+	jsonData, err := decodeJSONBody(body)
+	if err != nil {
+		return plog.Logs{}, err
+	}
+
+	return r.parseJSONDoc(jsonData, body, target, pollTime, logs, marks)
+}
+
+// decodeJSONBody decodes a JSON response body.
+func decodeJSONBody(body []byte) (interface{}, error) {
 	var jsonData interface{}
-	if err := json.Unmarshal(body, &jsonData); err != nil {
-		return plog.Logs{}, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	if err := unmarshalJSON(body, &jsonData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+	return jsonData, nil
+}
+
+// parseJSONDoc creates log records from the decoded JSON body.
+func (r *logsReceiver) parseJSONDoc(jsonData interface{}, body []byte, target *targetConfig, pollTime time.Time, logs plog.Logs, marks *recordMarks) (plog.Logs, error) {
+	// OTLP/JSON detected in auto mode is forwarded as is
+	if target.Format != formatJSON && target.RecordsPath == "" && isOTLPJSON(jsonData) {
+		return parseOTLPLogs(body, formatOTLPJSON, pollTime)
+	}
+
+	scopeLogs := appendTargetScopeLogs(logs, target)
+
+	envelope := r.extractEnvelopeAttributes(jsonData, target)
+
+	records := jsonData
+	if target.RecordsPath != "" {
+		records = r.extractValueByPath(target.RecordsPath, jsonData)
+		if records == nil {
+			r.logger.Debug("Records path not found in response",
+				zap.String("endpoint", target.Endpoint),
+				zap.String("records_path", target.RecordsPath))
+			return logs, nil
+		}
+	}
 
+	// An array yields one log record per element
+	if elements, ok := records.([]interface{}); ok {
+		for _, element := range elements {
+			r.addLogRecord(scopeLogs, element, envelope, pollTime, target, marks)
+		}
+		return logs, nil
 	}
 
+	r.addLogRecord(scopeLogs, records, envelope, pollTime, target, marks)
+
+	return logs, nil
+}
+
+// unmarshalJSON decodes a JSON value, keeping numbers as json.Number so large
+// integers such as nanosecond epochs and IDs are not rounded to float64.
+func unmarshalJSON(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("unexpected data after JSON value")
+	}
+	return nil
+}
+
+// appendTargetScopeLogs adds the resource of the target to logs and returns
+// the scope its JSON records are added to.
+func appendTargetScopeLogs(logs plog.Logs, target *targetConfig) plog.ScopeLogs {
 	resourceLogs := logs.ResourceLogs().AppendEmpty()
 	resource := resourceLogs.Resource()
 
 	resource.Attributes().PutStr("endpoint", target.Endpoint)
 	resource.Attributes().PutStr("service.name", target.ServiceName)
 
-	scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
+	return resourceLogs.ScopeLogs().AppendEmpty()
+}
+
+// extractEnvelopeAttributes resolves the configured envelope fields against the whole response.
+func (r *logsReceiver) extractEnvelopeAttributes(data interface{}, target *targetConfig) map[string]string {
+	if len(target.EnvelopeAttributes) == 0 {
+		return nil
+	}
 
-	r.addLogRecord(scopeLogs, jsonData, target)
+	attrs := make(map[string]string, len(target.EnvelopeAttributes))
+	for key, path := range target.EnvelopeAttributes {
+		if val := r.extractValueByPath(path, data); val != nil {
+			attrs[key] = fmt.Sprintf("%v", val)
+		} else {
+			attrs[key] = "NOT FOUND"
+		}
+	}
 
-	return logs, nil
+	return attrs
 }
 
 // parseTextLogs parses text formatted logs.
-func (r *logsReceiver) parseTextLogs(body []byte, target *targetConfig, logs plog.Logs) (plog.Logs, error) {
+func (r *logsReceiver) parseTextLogs(body []byte, target *targetConfig, pollTime time.Time, logs plog.Logs) (plog.Logs, error) {
 	resourceLogs := logs.ResourceLogs().AppendEmpty()
 	resource := resourceLogs.Resource()
 
@@ -230,31 +656,51 @@ func (r *logsReceiver) parseTextLogs(body []byte, target *targetConfig, logs plo
 
 	scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
 
-	// Split text into lines and create log records
-	lines := strings.Split(string(body), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
+	// Split text into lines, or multiline entries, and create log records
+	for _, line := range splitTextEntries(string(body), target.Multiline) {
+		if target.Regex != nil && r.addRegexRecord(scopeLogs, line, pollTime, target) {
 			continue
 		}
-
-		logRecord := scopeLogs.LogRecords().AppendEmpty()
-		logRecord.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
-		logRecord.Body().SetStr(line)
-		logRecord.SetSeverityText(strings.ToUpper(target.LogLevel))
-		logRecord.SetSeverityNumber(r.getSeverityNumber(target.LogLevel))
+		r.addTextRecord(scopeLogs, line, pollTime, target)
 	}
 
 	return logs, nil
 }
 
-// addLogRecord adds a single log record to the scope logs.
-func (r *logsReceiver) addLogRecord(scopeLogs plog.ScopeLogs, data interface{}, target *targetConfig) {
+// addTextRecord adds a plain text record with the severity of the target log_level.
+func (r *logsReceiver) addTextRecord(scopeLogs plog.ScopeLogs, line string, pollTime time.Time, target *targetConfig) {
 	logRecord := scopeLogs.LogRecords().AppendEmpty()
-	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
-
+	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(pollTime))
+	logRecord.SetObservedTimestamp(pcommon.NewTimestampFromTime(pollTime))
+	logRecord.Body().SetStr(line)
 	logRecord.SetSeverityText(strings.ToUpper(target.LogLevel))
 	logRecord.SetSeverityNumber(r.getSeverityNumber(target.LogLevel))
+}
+
+// addLogRecord adds a single log record to the scope logs and returns it.
+// Labels are extracted from data, which is the decoded JSON of this record
+// only, while envelope attributes are shared by every record of the response.
+func (r *logsReceiver) addLogRecord(scopeLogs plog.ScopeLogs, data interface{}, envelope map[string]string, pollTime time.Time, target *targetConfig, marks *recordMarks) plog.LogRecord {
+	logRecord := scopeLogs.LogRecords().AppendEmpty()
+	ts, ok := r.recordTimestamp(data, pollTime, target.Timestamp, target)
+	if ok {
+		marks.addTimestamp(ts)
+	}
+	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	logRecord.SetObservedTimestamp(pcommon.NewTimestampFromTime(pollTime))
+
+	if target.IDField != "" {
+		marks.lastID = ""
+		if id := r.extractValueByPath(target.IDField, data); id != nil {
+			marks.lastID = fmt.Sprintf("%v", id)
+		}
+	}
+
+	r.setSeverity(logRecord, data, target.Severity, target)
+
+	for key, value := range envelope {
+		logRecord.Attributes().PutStr(key, value)
+	}
 
 	for key, labelVal := range target.Labels {
 		if val := r.extractValueByPath(labelVal, data); val != nil {
@@ -265,6 +711,50 @@ func (r *logsReceiver) addLogRecord(scopeLogs plog.ScopeLogs, data interface{},
 	}
 
 	r.setBodyValue(logRecord.Body(), data)
+
+	return logRecord
+}
+
+// recordTimestamp returns the event time of a record, falling back to pollTime
+// when no timestamp field is configured or it cannot be parsed. ok reports
+// whether the time was taken from the record.
+func (r *logsReceiver) recordTimestamp(data interface{}, pollTime time.Time, cfg *timestampConfig, target *targetConfig) (time.Time, bool) {
+	if cfg == nil {
+		return pollTime, false
+	}
+
+	raw := r.extractValueByPath(cfg.Field, data)
+	if raw == nil {
+		return pollTime, false
+	}
+
+	ts, err := parseTimestamp(raw, cfg)
+	if err != nil {
+		r.logger.Debug("Failed to parse record timestamp",
+			zap.String("endpoint", target.Endpoint),
+			zap.String("field", cfg.Field),
+			zap.Error(err))
+		return pollTime, false
+	}
+
+	return ts, true
+}
+
+// setSeverity sets the record severity from its severity field, falling back
+// to the target log_level when the field is missing or unmapped.
+func (r *logsReceiver) setSeverity(logRecord plog.LogRecord, data interface{}, cfg *severityConfig, target *targetConfig) {
+	if cfg != nil {
+		if raw := r.extractValueByPath(cfg.Field, data); raw != nil {
+			if severity, ok := cfg.mapper.lookup(raw); ok {
+				logRecord.SetSeverityText(fmt.Sprintf("%v", raw))
+				logRecord.SetSeverityNumber(severity)
+				return
+			}
+		}
+	}
+
+	logRecord.SetSeverityText(strings.ToUpper(target.LogLevel))
+	logRecord.SetSeverityNumber(r.getSeverityNumber(target.LogLevel))
 }
 
 // setBodyValue recursively populates a pcommon.Value from an interface{} decoded from JSON.
@@ -286,6 +776,16 @@ func (r *logsReceiver) setBodyValue(dest pcommon.Value, v interface{}) {
 		dest.SetStr(val)
 	case float64:
 		dest.SetDouble(val)
+	case json.Number:
+		// Integers stay exact, e.g. IDs compared against the stored last ID
+		if i, err := val.Int64(); err == nil {
+			dest.SetInt(i)
+			break
+		}
+		f, _ := val.Float64()
+		dest.SetDouble(f)
+	case int64:
+		dest.SetInt(val)
 	case bool:
 		dest.SetBool(val)
 	case nil:
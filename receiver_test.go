@@ -2,14 +2,23 @@ package logsreceiver
 
 import (
 	"context"
+	"encoding/pem"
 	"net/http"
 	"net/http/httptest"
-	"strings"
+	"os"
+	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -57,7 +66,7 @@ func waitForLogs(t *testing.T, sink *testLogsSink, minRecords int, timeout time.
 }
 
 func TestLogsReceiver_CommentsEmails(t *testing.T) {
-	// Array response now yields ONE log record whose body is a slice of objects
+	// Array response yields one log record per element
 	commentsJSON := `[{"postId":1,"id":1,"name":"id labore ex et quam laborum","email":"Eliseo@gardner.biz"},{"postId":1,"id":2,"name":"quo vero reiciendis velit similique earum","email":"Jayne_Kuhic@sydney.com"}]`
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -65,7 +74,7 @@ func TestLogsReceiver_CommentsEmails(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	cfg := &Config{CollectionInterval: 20 * time.Millisecond, Targets: []*targetConfig{{Endpoint: srv.URL, Method: "GET", LogLevel: "debug", ServiceName: "scrap-comments", Labels: map[string]string{"user_emails": "email"}}}}
+	cfg := &Config{CollectionInterval: 20 * time.Millisecond, Targets: []*targetConfig{{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}, Method: "GET", LogLevel: "debug", ServiceName: "scrap-comments", Labels: map[string]string{"user_emails": "email"}}}}
 	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
 	sink := &testLogsSink{}
 	r := newLogsReceiver(cfg, settings, sink)
@@ -74,28 +83,27 @@ func TestLogsReceiver_CommentsEmails(t *testing.T) {
 	if err := r.Start(ctx, nil); err != nil {
 		t.Fatalf("Start failed: %v", err)
 	}
-	logs := waitForLogs(t, sink, 1, 600*time.Millisecond)
-	if logs.LogRecordCount() != 1 {
-		_ = r.Shutdown(ctx)
-		t.Fatalf("expected 1 log record for array top-level, got %d", logs.LogRecordCount())
-	}
-	lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
-	val, ok := lr.Attributes().Get("user_emails")
-	if !ok {
+	logs := waitForLogs(t, sink, 2, 600*time.Millisecond)
+	if logs.LogRecordCount() != 2 {
 		_ = r.Shutdown(ctx)
-		t.Fatalf("expected user_emails attribute")
-	}
-	attrStr := val.Str()
-	if !strings.Contains(attrStr, "Eliseo@gardner.biz") || !strings.Contains(attrStr, "Jayne_Kuhic@sydney.com") {
-		_ = r.Shutdown(ctx)
-		t.Errorf("expected aggregated emails, got %s", attrStr)
-	}
-	// verify structured body slice length
-	if lr.Body().Type() != pcommon.ValueTypeSlice {
-		t.Errorf("expected slice body, got %v", lr.Body().Type())
-	}
-	if lr.Body().Slice().Len() != 2 {
-		t.Errorf("expected body slice len 2 got %d", lr.Body().Slice().Len())
+		t.Fatalf("expected 2 log records for array top-level, got %d", logs.LogRecordCount())
+	}
+	records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	expected := []string{"Eliseo@gardner.biz", "Jayne_Kuhic@sydney.com"}
+	for i, email := range expected {
+		lr := records.At(i)
+		val, ok := lr.Attributes().Get("user_emails")
+		if !ok {
+			_ = r.Shutdown(ctx)
+			t.Fatalf("expected user_emails attribute on record %d", i)
+		}
+		if val.Str() != email {
+			t.Errorf("expected user_emails %s on record %d, got %s", email, i, val.Str())
+		}
+		// verify each record carries its own structured element
+		if lr.Body().Type() != pcommon.ValueTypeMap {
+			t.Errorf("expected map body, got %v", lr.Body().Type())
+		}
 	}
 	if err := r.Shutdown(ctx); err != nil {
 		t.Fatalf("Shutdown failed: %v", err)
@@ -110,7 +118,7 @@ func TestLogsReceiver_UsersCompanies(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	cfg := &Config{CollectionInterval: 25 * time.Millisecond, Targets: []*targetConfig{{Endpoint: srv.URL, Method: "GET", LogLevel: "info", Labels: map[string]string{"companies": "company.name"}}}}
+	cfg := &Config{CollectionInterval: 25 * time.Millisecond, Targets: []*targetConfig{{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}, Method: "GET", LogLevel: "info", Labels: map[string]string{"companies": "company.name"}}}}
 	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
 	sink := &testLogsSink{}
 	r := newLogsReceiver(cfg, settings, sink)
@@ -119,27 +127,27 @@ func TestLogsReceiver_UsersCompanies(t *testing.T) {
 	if err := r.Start(ctx, nil); err != nil {
 		t.Fatalf("Start failed: %v", err)
 	}
-	logs := waitForLogs(t, sink, 1, 600*time.Millisecond)
-	if logs.LogRecordCount() != 1 {
-		_ = r.Shutdown(ctx)
-		t.Fatalf("expected 1 log record for array, got %d", logs.LogRecordCount())
-	}
-	lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
-	val, ok := lr.Attributes().Get("companies")
-	if !ok {
-		_ = r.Shutdown(ctx)
-		t.Fatalf("expected companies attribute")
-	}
-	attrStr := val.Str()
-	if !strings.Contains(attrStr, "Romaguera-Crona") || !strings.Contains(attrStr, "Deckow-Crist") {
+	logs := waitForLogs(t, sink, 2, 600*time.Millisecond)
+	if logs.LogRecordCount() != 2 {
 		_ = r.Shutdown(ctx)
-		t.Errorf("expected aggregated company names, got %s", attrStr)
-	}
-	if lr.Body().Type() != pcommon.ValueTypeSlice {
-		t.Errorf("expected slice body for users array")
-	}
-	if lr.Body().Slice().Len() != 2 {
-		t.Errorf("expected body slice len 2 got %d", lr.Body().Slice().Len())
+		t.Fatalf("expected 2 log records for array, got %d", logs.LogRecordCount())
+	}
+	records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	expected := []string{"Romaguera-Crona", "Deckow-Crist"}
+	for i, company := range expected {
+		lr := records.At(i)
+		val, ok := lr.Attributes().Get("companies")
+		if !ok {
+			_ = r.Shutdown(ctx)
+			t.Fatalf("expected companies attribute on record %d", i)
+		}
+		if val.Str() != company {
+			t.Errorf("expected company %s on record %d, got %s", company, i, val.Str())
+		}
+		name, ok := lr.Body().Map().Get("name")
+		if !ok || name.Str() == "" {
+			t.Errorf("expected name in body of record %d", i)
+		}
 	}
 	if err := r.Shutdown(ctx); err != nil {
 		t.Fatalf("Shutdown failed: %v", err)
@@ -154,7 +162,7 @@ func TestLogsReceiver_JSONObject_StructuredBodyAndMissingLabel(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	cfg := &Config{CollectionInterval: 10 * time.Millisecond, Targets: []*targetConfig{{Endpoint: srv.URL, Method: "GET", LogLevel: "info", Labels: map[string]string{"id_field": "id", "missing_field": "does.not.exist"}}}}
+	cfg := &Config{CollectionInterval: 10 * time.Millisecond, Targets: []*targetConfig{{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}, Method: "GET", LogLevel: "info", Labels: map[string]string{"id_field": "id", "missing_field": "does.not.exist"}}}}
 	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
 	sink := &testLogsSink{}
 	r := newLogsReceiver(cfg, settings, sink)
@@ -183,6 +191,361 @@ func TestLogsReceiver_JSONObject_StructuredBodyAndMissingLabel(t *testing.T) {
 	}
 }
 
+func TestLogsReceiver_RecordsPathWithEnvelopeAttributes(t *testing.T) {
+	envelopeJSON := `{"data":{"items":[{"id":1,"user":"alice"},{"id":2,"user":"bob"}]},"meta":{"page":3,"source":"audit"}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(envelopeJSON))
+	}))
+	defer srv.Close()
+
+	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{}, settings, sink)
+	target := &targetConfig{
+		ClientConfig:       confighttp.ClientConfig{Endpoint: srv.URL},
+		Method:             "GET",
+		RecordsPath:        "data.items",
+		EnvelopeAttributes: map[string]string{"source": "meta.source", "page": "meta.page", "missing": "meta.nope"},
+		Labels:             map[string]string{"user": "user"},
+	}
+	if err := r.pollTarget(context.Background(), target); err != nil {
+		t.Fatalf("pollTarget failed: %v", err)
+	}
+	logs := sink.AllLogs()
+	if len(logs) != 1 || logs[0].LogRecordCount() != 2 {
+		t.Fatalf("expected 2 log records from records_path")
+	}
+	records := logs[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	for i, user := range []string{"alice", "bob"} {
+		attrs := records.At(i).Attributes()
+		if v, ok := attrs.Get("user"); !ok || v.Str() != user {
+			t.Errorf("expected user %s on record %d, got %v", user, i, v)
+		}
+		if v, ok := attrs.Get("source"); !ok || v.Str() != "audit" {
+			t.Errorf("expected source envelope attribute on record %d, got %v", i, v)
+		}
+		if v, ok := attrs.Get("page"); !ok || v.Str() != "3" {
+			t.Errorf("expected page envelope attribute on record %d, got %v", i, v)
+		}
+		if v, ok := attrs.Get("missing"); !ok || v.Str() != "NOT FOUND" {
+			t.Errorf("expected missing envelope attribute NOT FOUND on record %d, got %v", i, v)
+		}
+	}
+}
+
+func TestLogsReceiver_TimestampField(t *testing.T) {
+	eventsJSON := `[{"id":1,"created":"2025-10-15T10:20:30Z"},{"id":2,"created":"garbage"},{"id":3}]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(eventsJSON))
+	}))
+	defer srv.Close()
+
+	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{}, settings, sink)
+	target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}, Method: "GET", Timestamp: &timestampConfig{Field: "created"}}
+	if err := target.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if err := r.pollTarget(context.Background(), target); err != nil {
+		t.Fatalf("pollTarget failed: %v", err)
+	}
+	logs := sink.AllLogs()
+	if len(logs) != 1 || logs[0].LogRecordCount() != 3 {
+		t.Fatalf("expected 3 log records")
+	}
+	records := logs[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	want := time.Date(2025, 10, 15, 10, 20, 30, 0, time.UTC)
+	if got := records.At(0).Timestamp().AsTime(); !got.Equal(want) {
+		t.Errorf("expected timestamp %v got %v", want, got)
+	}
+	for i := 0; i < records.Len(); i++ {
+		lr := records.At(i)
+		if lr.ObservedTimestamp() == 0 {
+			t.Errorf("expected observed timestamp on record %d", i)
+		}
+		// unparsable and missing fields fall back to the poll time
+		if i > 0 && lr.Timestamp() != lr.ObservedTimestamp() {
+			t.Errorf("expected fallback to poll time on record %d", i)
+		}
+	}
+}
+
+func TestLogsReceiver_TimestampFieldEpochPrecision(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"ts":1760523630123456789}]`))
+	}))
+	defer srv.Close()
+
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), sink)
+	target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}, Timestamp: &timestampConfig{Field: "ts", LayoutType: layoutTypeEpoch, Layout: "ns"}}
+	if err := target.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if err := r.pollTarget(context.Background(), target); err != nil {
+		t.Fatalf("pollTarget failed: %v", err)
+	}
+
+	// a float64 would round the epoch to the nearest 256ns
+	record := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	if got := record.Timestamp().AsTime().UnixNano(); got != 1760523630123456789 {
+		t.Errorf("expected timestamp 1760523630123456789 got %d", got)
+	}
+}
+
+func TestLogsReceiver_SeverityField(t *testing.T) {
+	eventsJSON := `[{"level":"E"},{"level":30},{"level":"warning"},{"msg":"no level"},{"level":"verbose"}]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(eventsJSON))
+	}))
+	defer srv.Close()
+
+	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{}, settings, sink)
+	target := &targetConfig{
+		ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL},
+		Method:       "GET",
+		LogLevel:     "debug",
+		Severity: &severityConfig{Field: "level", Mapping: map[string][]interface{}{
+			"error": {"E"},
+			"info":  {map[string]interface{}{"min": 30, "max": 39}},
+		}},
+	}
+	if err := target.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if err := r.pollTarget(context.Background(), target); err != nil {
+		t.Fatalf("pollTarget failed: %v", err)
+	}
+	logs := sink.AllLogs()
+	if len(logs) != 1 || logs[0].LogRecordCount() != 5 {
+		t.Fatalf("expected 5 log records")
+	}
+	records := logs[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	expected := []struct {
+		number plog.SeverityNumber
+		text   string
+	}{
+		{plog.SeverityNumberError, "E"},
+		{plog.SeverityNumberInfo, "30"},
+		{plog.SeverityNumberWarn, "warning"},
+		{plog.SeverityNumberDebug, "DEBUG"},
+		{plog.SeverityNumberDebug, "DEBUG"},
+	}
+	for i, want := range expected {
+		lr := records.At(i)
+		if lr.SeverityNumber() != want.number || lr.SeverityText() != want.text {
+			t.Errorf("record %d: expected %v/%s got %v/%s", i, want.number, want.text, lr.SeverityNumber(), lr.SeverityText())
+		}
+	}
+}
+
+func TestLogsReceiver_ClientConfigTLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"msg":"secure"}`))
+	}))
+	defer srv.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatalf("failed to write CA file: %v", err)
+	}
+
+	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{}, settings, sink)
+
+	untrusted := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}}
+	if err := r.pollTarget(context.Background(), untrusted); err == nil {
+		t.Fatalf("expected certificate verification error without CA")
+	}
+
+	defaults := newDefaultTargetConfig()
+	target := &defaults
+	target.Endpoint = srv.URL
+	target.TLS = configtls.ClientConfig{Config: configtls.Config{CAFile: caFile}}
+	if err := target.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := r.pollTarget(context.Background(), target); err != nil {
+			t.Fatalf("pollTarget failed: %v", err)
+		}
+	}
+	if len(sink.AllLogs()) != 2 {
+		t.Errorf("expected logs from both polls")
+	}
+
+	// the client is built once per target and reused across polls
+	first, _ := r.getClient(context.Background(), target)
+	second, _ := r.getClient(context.Background(), target)
+	if first != second {
+		t.Errorf("expected the target client to be reused")
+	}
+	if first.Timeout != 30*time.Second {
+		t.Errorf("expected default timeout of 30s, got %v", first.Timeout)
+	}
+}
+
+// bearerAuthExtension is an extensionauth.HTTPClient that sets a bearer token.
+type bearerAuthExtension struct {
+	token string
+}
+
+func (e *bearerAuthExtension) Start(context.Context, component.Host) error { return nil }
+
+func (e *bearerAuthExtension) Shutdown(context.Context) error { return nil }
+
+func (e *bearerAuthExtension) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+e.token)
+		return base.RoundTrip(req)
+	}), nil
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestLogsReceiver_AuthExtension(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t0k3n" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"msg":"authorized"}`))
+	}))
+	defer srv.Close()
+
+	authID := component.MustNewID("bearertokenauth")
+	newTarget := func() *targetConfig {
+		target := &targetConfig{ClientConfig: confighttp.ClientConfig{
+			Endpoint: srv.URL,
+			Auth:     configoptional.Some(configauth.Config{AuthenticatorID: authID}),
+		}}
+		if err := target.Validate(); err != nil {
+			t.Fatalf("Validate failed: %v", err)
+		}
+		return target
+	}
+	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
+
+	// the authenticator is resolved from the host, so it is unavailable before Start
+	unstarted := newLogsReceiver(&Config{}, settings, &testLogsSink{})
+	if err := unstarted.pollTarget(context.Background(), newTarget()); err == nil {
+		t.Fatalf("expected error resolving auth before Start")
+	}
+
+	missing := newLogsReceiver(&Config{CollectionInterval: time.Hour, Targets: []*targetConfig{newTarget()}}, settings, &testLogsSink{})
+	if err := missing.Start(context.Background(), extensionsHost{}); err == nil {
+		t.Fatalf("expected Start to fail for an unknown authenticator")
+	}
+
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{CollectionInterval: time.Hour, Targets: []*targetConfig{newTarget()}}, settings, sink)
+	host := extensionsHost{authID: &bearerAuthExtension{token: "t0k3n"}}
+	if err := r.Start(context.Background(), host); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer func() {
+		if err := r.Shutdown(context.Background()); err != nil {
+			t.Errorf("Shutdown failed: %v", err)
+		}
+	}()
+
+	logs := waitForLogs(t, sink, 1, 2*time.Second)
+	body := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Map()
+	if msg, _ := body.Get("msg"); msg.Str() != "authorized" {
+		t.Errorf("expected authorized response, got %q", msg.Str())
+	}
+}
+
+func TestLogsReceiver_IndependentSchedules(t *testing.T) {
+	var fastPolls, slowPolls, slowInFlight, slowMaxInFlight, delayedPolls atomic.Int32
+	var firstDelayedPoll atomic.Int64
+	release := make(chan struct{})
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fastPolls.Add(1)
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("fast"))
+	}))
+	defer fast.Close()
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		slowPolls.Add(1)
+		if n := slowInFlight.Add(1); n > slowMaxInFlight.Load() {
+			slowMaxInFlight.Store(n)
+		}
+		defer slowInFlight.Add(-1)
+		// the first poll hangs until the test releases it
+		<-release
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("slow"))
+	}))
+	defer slow.Close()
+	delayed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		delayedPolls.Add(1)
+		firstDelayedPoll.CompareAndSwap(0, time.Now().UnixNano())
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("delayed"))
+	}))
+	defer delayed.Close()
+
+	cfg := &Config{
+		CollectionInterval: time.Hour,
+		Targets: []*targetConfig{
+			{ClientConfig: confighttp.ClientConfig{Endpoint: fast.URL}, CollectionInterval: 20 * time.Millisecond},
+			{ClientConfig: confighttp.ClientConfig{Endpoint: slow.URL}, CollectionInterval: 10 * time.Millisecond},
+			{ClientConfig: confighttp.ClientConfig{Endpoint: delayed.URL}, InitialDelay: 300 * time.Millisecond},
+		},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
+	r := newLogsReceiver(cfg, settings, &testLogsSink{})
+	started := time.Now()
+	if err := r.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	// the fast target keeps its cadence while a poll of the slow target hangs
+	require.Eventually(t, func() bool { return fastPolls.Load() >= 5 }, 10*time.Second, 5*time.Millisecond)
+	if n := slowPolls.Load(); n > 1 {
+		t.Errorf("expected the hanging slow poll to hold back the next one, got %d polls", n)
+	}
+
+	close(release)
+	require.Eventually(t, func() bool { return slowPolls.Load() >= 3 && delayedPolls.Load() >= 1 }, 10*time.Second, 5*time.Millisecond)
+
+	if err := r.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	if n := slowMaxInFlight.Load(); n != 1 {
+		t.Errorf("expected polls of the slow target not to overlap, got %d concurrent", n)
+	}
+	// the delayed target is polled once, not before its initial delay, and then waits for the hour-long interval
+	if n := delayedPolls.Load(); n != 1 {
+		t.Errorf("expected a single poll after the initial delay, got %d", n)
+	}
+	if waited := time.Unix(0, firstDelayedPoll.Load()).Sub(started); waited < 300*time.Millisecond {
+		t.Errorf("expected no poll before the initial delay, got one after %v", waited)
+	}
+}
+
 func TestLogsReceiver_TextLines(t *testing.T) {
 	textResp := "alpha\nbeta\n\n gamma "
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -191,7 +554,7 @@ func TestLogsReceiver_TextLines(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	cfg := &Config{CollectionInterval: 100 * time.Millisecond, Targets: []*targetConfig{{Endpoint: srv.URL, Method: "GET", LogLevel: "info"}}}
+	cfg := &Config{CollectionInterval: 100 * time.Millisecond, Targets: []*targetConfig{{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}, Method: "GET", LogLevel: "info"}}}
 	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
 	sink := &testLogsSink{}
 	r := newLogsReceiver(cfg, settings, sink)
@@ -220,7 +583,7 @@ func TestLogsReceiver_PollTarget_HTTPError(t *testing.T) {
 
 	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
 	r := newLogsReceiver(&Config{}, settings, &testLogsSink{})
-	target := &targetConfig{Endpoint: srv.URL, Method: "GET"}
+	target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}, Method: "GET"}
 	if err := r.pollTarget(context.Background(), target); err == nil {
 		t.Fatalf("expected error for 500 response")
 	}
@@ -236,7 +599,7 @@ func TestLogsReceiver_PollTarget_InvalidJSON(t *testing.T) {
 
 	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
 	r := newLogsReceiver(&Config{}, settings, &testLogsSink{})
-	target := &targetConfig{Endpoint: srv.URL, Method: "GET"}
+	target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}, Method: "GET"}
 	if err := r.pollTarget(context.Background(), target); err == nil {
 		t.Fatalf("expected JSON unmarshal error")
 	}
@@ -252,7 +615,7 @@ func TestLogsReceiver_ParseLogs_UnknownContentType(t *testing.T) {
 
 	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
 	r := newLogsReceiver(&Config{}, settings, &testLogsSink{})
-	target := &targetConfig{Endpoint: srv.URL, Method: "GET"}
+	target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}, Method: "GET"}
 	if err := r.pollTarget(context.Background(), target); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -261,8 +624,8 @@ func TestLogsReceiver_ParseLogs_UnknownContentType(t *testing.T) {
 func TestLogsReceiver_CreateRequest_DefaultContentType_JSON(t *testing.T) {
 	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
 	r := newLogsReceiver(&Config{}, settings, &testLogsSink{})
-	target := &targetConfig{Endpoint: "http://example.com", Method: "POST", Body: "{\"k\":\"v\"}"}
-	req, err := r.createRequest(context.Background(), target)
+	target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: "http://example.com"}, Method: "POST", Body: "{\"k\":\"v\"}"}
+	req, err := r.createRequest(context.Background(), target, templateVars{})
 	if err != nil {
 		t.Fatalf("createRequest failed: %v", err)
 	}
@@ -274,8 +637,8 @@ func TestLogsReceiver_CreateRequest_DefaultContentType_JSON(t *testing.T) {
 func TestLogsReceiver_CreateRequest_DefaultContentType_Text(t *testing.T) {
 	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
 	r := newLogsReceiver(&Config{}, settings, &testLogsSink{})
-	target := &targetConfig{Endpoint: "http://example.com", Method: "POST", Body: "plain text"}
-	req, err := r.createRequest(context.Background(), target)
+	target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: "http://example.com"}, Method: "POST", Body: "plain text"}
+	req, err := r.createRequest(context.Background(), target, templateVars{})
 	if err != nil {
 		t.Fatalf("createRequest failed: %v", err)
 	}
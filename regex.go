@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// Regex capture groups that set record fields instead of attributes
+const (
+	regexGroupTimestamp = "timestamp"
+	regexGroupSeverity  = "severity"
+	regexGroupBody      = "body"
+)
+
+// addRegexRecord adds a record for a text line matched by the target's regex.
+// Named groups become attributes, while the timestamp, severity and body
+// groups set the corresponding record fields. It reports false, adding
+// nothing, when the line does not match.
+func (r *logsReceiver) addRegexRecord(scopeLogs plog.ScopeLogs, line string, pollTime time.Time, target *targetConfig) bool {
+	re := target.Regex.regexp
+	match := re.FindStringSubmatch(line)
+	if match == nil {
+		return false
+	}
+
+	// Groups that did not participate in the match are left out
+	captures := map[string]interface{}{}
+	for i, name := range re.SubexpNames() {
+		if name != "" && match[i] != "" {
+			captures[name] = match[i]
+		}
+	}
+
+	logRecord := scopeLogs.LogRecords().AppendEmpty()
+	ts, _ := r.recordTimestamp(captures, pollTime, target.Regex.timestamp, target)
+	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	logRecord.SetObservedTimestamp(pcommon.NewTimestampFromTime(pollTime))
+
+	r.setSeverity(logRecord, captures, target.Regex.severity, target)
+
+	for name, value := range captures {
+		switch name {
+		case regexGroupTimestamp, regexGroupSeverity, regexGroupBody:
+		default:
+			logRecord.Attributes().PutStr(name, value.(string))
+		}
+	}
+
+	if body, ok := captures[regexGroupBody]; ok {
+		logRecord.Body().SetStr(body.(string))
+	} else {
+		logRecord.Body().SetStr(line)
+	}
+
+	return true
+}
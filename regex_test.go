@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestLogsReceiver_Regex(t *testing.T) {
+	const accessLog = `203.0.113.7 - - [15/Oct/2025:10:00:00 +0200] "GET /index.html HTTP/1.1" 200 512
+198.51.100.2 - - [15/Oct/2025:10:00:05 +0200] "POST /login HTTP/1.1" 401 -
+-- rotated --
+`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(accessLog))
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{
+		ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL},
+		Regex: &regexConfig{
+			Pattern: `^(?P<client>\S+) \S+ \S+ \[(?P<time>[^\]]+)\] "(?P<body>[^"]*)" (?P<status>\d{3}) (?:(?P<bytes>\d+)|-)$`,
+		},
+		Timestamp: &timestampConfig{Field: "time", LayoutType: layoutTypeStrptime, Layout: "%d/%b/%Y:%H:%M:%S %z"},
+		Severity:  &severityConfig{Field: "status", Mapping: map[string][]interface{}{"warn": {map[string]interface{}{"min": 400, "max": 499}}}},
+	}
+	require.NoError(t, target.Validate())
+
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), sink)
+	require.NoError(t, r.pollTarget(context.Background(), target))
+
+	logs := sink.AllLogs()
+	require.Len(t, logs, 1)
+	records := logs[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 3, records.Len())
+
+	first := records.At(0)
+	assert.Equal(t, "GET /index.html HTTP/1.1", first.Body().Str())
+	assert.Equal(t, map[string]interface{}{"client": "203.0.113.7", "time": "15/Oct/2025:10:00:00 +0200", "status": "200", "bytes": "512"}, first.Attributes().AsRaw())
+	assert.Equal(t, time.Date(2025, 10, 15, 8, 0, 0, 0, time.UTC), first.Timestamp().AsTime())
+	assert.Equal(t, plog.SeverityNumberInfo, first.SeverityNumber())
+
+	// the optional bytes group did not participate in the match
+	second := records.At(1)
+	assert.Equal(t, map[string]interface{}{"client": "198.51.100.2", "time": "15/Oct/2025:10:00:05 +0200", "status": "401"}, second.Attributes().AsRaw())
+	assert.Equal(t, plog.SeverityNumberWarn, second.SeverityNumber())
+
+	// an unmatched line is kept as a plain text record
+	third := records.At(2)
+	assert.Equal(t, "-- rotated --", third.Body().Str())
+	assert.Equal(t, 0, third.Attributes().Len())
+}
+
+func TestLogsReceiver_RegexSpecialGroups(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("2025-10-15T10:00:00Z ERROR [billing] invoice 42 failed\n2025-10-15T10:00:01Z NOTICE [billing] retrying\n"))
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{
+		ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL},
+		LogLevel:     "debug",
+		Regex:        &regexConfig{Pattern: `^(?P<timestamp>\S+) (?P<severity>\w+) \[(?P<component>\w+)\] (?P<body>.*)$`},
+	}
+	require.NoError(t, target.Validate())
+
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), sink)
+	require.NoError(t, r.pollTarget(context.Background(), target))
+
+	records := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+
+	first := records.At(0)
+	assert.Equal(t, "invoice 42 failed", first.Body().Str())
+	assert.Equal(t, map[string]interface{}{"component": "billing"}, first.Attributes().AsRaw())
+	assert.Equal(t, time.Date(2025, 10, 15, 10, 0, 0, 0, time.UTC), first.Timestamp().AsTime())
+	assert.Equal(t, plog.SeverityNumberError, first.SeverityNumber())
+	assert.Equal(t, "ERROR", first.SeverityText())
+
+	// an unknown level falls back to log_level
+	assert.Equal(t, plog.SeverityNumberDebug, records.At(1).SeverityNumber())
+}
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// backoff returns the delay before the given retry (1 for the first retry),
+// doubling from the initial backoff up to the max backoff with jitter applied.
+func (cfg *retryConfig) backoff(retry int) time.Duration {
+	delay := cfg.InitialBackoff
+	for i := 1; i < retry && delay < cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > cfg.MaxBackoff {
+		delay = cfg.MaxBackoff
+	}
+
+	if cfg.Jitter != nil && *cfg.Jitter > 0 {
+		delay = time.Duration(float64(delay) * (1 + *cfg.Jitter*(2*rand.Float64()-1)))
+	}
+
+	return delay
+}
+
+// retryable reports whether a response with the status code is retried.
+func (cfg *retryConfig) retryable(statusCode int) bool {
+	return slices.Contains(cfg.StatusCodes, statusCode)
+}
+
+// parseRetryAfter parses a Retry-After header given either as delay seconds
+// or as an HTTP date. It reports false when the header is absent or invalid.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	delay := date.Sub(now)
+	if delay < 0 {
+		delay = 0
+	}
+
+	return delay, true
+}
+
+// retryableError reports whether a failed attempt may succeed when repeated.
+// Timeouts and connection failures are retried; certificate and TLS errors,
+// as well as requests the client cannot send at all, fail at once.
+func retryableError(err error) bool {
+	var (
+		verifyErr    *tls.CertificateVerificationError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+		recordErr    tls.RecordHeaderError
+	)
+	if errors.As(err, &verifyErr) || errors.As(err, &authorityErr) || errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr) || errors.As(err, &recordErr) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	// Failures to dial, read or write, other than a TLS alert from the server
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Op != "remote error"
+	}
+
+	// The server closed the connection before responding
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// doRequest executes the request, retrying timeouts, connection errors and
+// retryable status codes when the target has a retry config. The request is replayed
+// from its GetBody, so it must have been created with a rewindable body.
+// The last response or error is returned once attempts are exhausted.
+func (r *logsReceiver) doRequest(ctx context.Context, client *http.Client, req *http.Request, target *targetConfig) (*http.Response, error) {
+	cfg := target.Retry
+	if cfg == nil {
+		return r.doAttempt(ctx, client, req, target)
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 {
+			attemptReq = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, fmt.Errorf("failed to rewind request body: %w", err)
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := r.doAttempt(ctx, client, attemptReq, target)
+		if attempt >= cfg.MaxAttempts {
+			return resp, err
+		}
+
+		delay := cfg.backoff(attempt)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil || !retryableError(err) {
+				return nil, err
+			}
+		case cfg.retryable(resp.StatusCode):
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if retryAfter > cfg.MaxBackoff {
+					// Waiting that long would stall the target's schedule, so leave it to the next poll
+					return resp, nil
+				}
+				delay = retryAfter
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		default:
+			return resp, nil
+		}
+
+		r.logger.Warn("Retrying request",
+			zap.String("endpoint", target.Endpoint),
+			zap.Int("attempt", attempt),
+			zap.Duration("delay", delay),
+			zap.NamedError("cause", retryCause(resp, err)))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// doAttempt executes a single request attempt and records it in the receiver's telemetry.
+func (r *logsReceiver) doAttempt(ctx context.Context, client *http.Client, req *http.Request, target *targetConfig) (*http.Response, error) {
+	start := time.Now()
+	resp, err := client.Do(req)
+
+	statusCode := 0
+	if err == nil {
+		statusCode = resp.StatusCode
+	}
+	r.telemetry.recordRequest(ctx, target, time.Since(start), statusCode)
+
+	return resp, err
+}
+
+// retryCause describes why an attempt is retried.
+func retryCause(resp *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("HTTP error: %s", resp.Status)
+}
@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{value: "", wantOK: false},
+		{value: "3", want: 3 * time.Second, wantOK: true},
+		{value: " 0 ", want: 0, wantOK: true},
+		{value: "-1", wantOK: false},
+		{value: "Wed, 15 Oct 2025 12:00:30 GMT", want: 30 * time.Second, wantOK: true},
+		{value: "Wed, 15 Oct 2025 11:59:00 GMT", want: 0, wantOK: true},
+		{value: "soon", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		assert.Equal(t, tt.wantOK, ok, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+}
+
+func TestRetryConfig_Backoff(t *testing.T) {
+	jitter := 0.0
+	cfg := &retryConfig{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Jitter: &jitter}
+	assert.Equal(t, time.Second, cfg.backoff(1))
+	assert.Equal(t, 2*time.Second, cfg.backoff(2))
+	assert.Equal(t, 4*time.Second, cfg.backoff(3))
+	assert.Equal(t, 5*time.Second, cfg.backoff(4))
+	assert.Equal(t, 5*time.Second, cfg.backoff(50))
+
+	jitter = 0.5
+	for i := 0; i < 20; i++ {
+		delay := cfg.backoff(2)
+		assert.GreaterOrEqual(t, delay, time.Second)
+		assert.LessOrEqual(t, delay, 3*time.Second)
+	}
+}
+
+func TestRetryConfig_Validate(t *testing.T) {
+	cfg := &retryConfig{}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, 3, cfg.MaxAttempts)
+	assert.Equal(t, time.Second, cfg.InitialBackoff)
+	assert.Equal(t, 30*time.Second, cfg.MaxBackoff)
+	assert.InDelta(t, 0.2, *cfg.Jitter, 0)
+	assert.Equal(t, []int{429, 502, 503, 504}, cfg.StatusCodes)
+
+	jitter := 1.5
+	assert.Error(t, (&retryConfig{Jitter: &jitter}).Validate())
+	assert.Error(t, (&retryConfig{InitialBackoff: time.Minute, MaxBackoff: time.Second}).Validate())
+	assert.Error(t, (&retryConfig{StatusCodes: []int{200}}).Validate())
+	assert.Error(t, (&retryConfig{MaxAttempts: -1}).Validate())
+}
+
+func TestLogsReceiver_Retry(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []int
+		header    string
+		wantErr   bool
+		wantCalls int32
+	}{
+		{name: "transient errors then success", responses: []int{503, 502, 200}, wantCalls: 3},
+		{name: "retry after seconds", responses: []int{429, 200}, header: "0", wantCalls: 2},
+		{name: "retry after beyond max backoff", responses: []int{429, 200}, header: "120", wantErr: true, wantCalls: 1},
+		{name: "attempts exhausted", responses: []int{503, 503, 503, 200}, wantErr: true, wantCalls: 3},
+		{name: "not retryable", responses: []int{404, 200}, wantErr: true, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				assert.Equal(t, `{"q":1}`, string(body))
+				status := tt.responses[calls.Add(1)-1]
+				if tt.header != "" {
+					w.Header().Set("Retry-After", tt.header)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte(`{"msg":"ok"}`))
+			}))
+			defer srv.Close()
+
+			target := &targetConfig{
+				ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL},
+				Method:       "POST",
+				Body:         `{"q":1}`,
+				Retry:        &retryConfig{InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond},
+			}
+			require.NoError(t, target.Validate())
+
+			sink := &testLogsSink{}
+			r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), sink)
+			err := r.pollTarget(context.Background(), target)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, sink.AllLogs())
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, sink.AllLogs(), 1)
+			}
+			assert.Equal(t, tt.wantCalls, calls.Load())
+		})
+	}
+}
+
+func TestLogsReceiver_RetryCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{
+		ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL},
+		Retry:        &retryConfig{MaxAttempts: 5, InitialBackoff: time.Minute, MaxBackoff: time.Minute},
+	}
+	require.NoError(t, target.Validate())
+
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), &testLogsSink{})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := r.pollTarget(ctx, target)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestRetryableError(t *testing.T) {
+	requestErr := func(client *http.Client, url string) error {
+		resp, err := client.Get(url)
+		if err == nil {
+			resp.Body.Close()
+		}
+		require.Error(t, err)
+		return err
+	}
+
+	untrusted := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer untrusted.Close()
+
+	slow := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer slow.Close()
+
+	hangUp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		conn.Close()
+	}))
+	defer hangUp.Close()
+
+	closed := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	closed.Close()
+
+	assert.False(t, retryableError(requestErr(http.DefaultClient, untrusted.URL)), "untrusted certificate")
+	assert.False(t, retryableError(requestErr(http.DefaultClient, "ftp://example.com/logs")), "unsupported scheme")
+	assert.True(t, retryableError(requestErr(&http.Client{Timeout: 20 * time.Millisecond}, slow.URL)), "timeout")
+	assert.True(t, retryableError(requestErr(http.DefaultClient, hangUp.URL)), "connection closed")
+	assert.True(t, retryableError(requestErr(http.DefaultClient, closed.URL)), "connection refused")
+}
+
+func TestLogsReceiver_RetryCertificateError(t *testing.T) {
+	var connections atomic.Int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	target := &targetConfig{
+		ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL},
+		Retry:        &retryConfig{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+	}
+	require.NoError(t, target.Validate())
+
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), &testLogsSink{})
+	require.ErrorContains(t, r.pollTarget(context.Background(), target), "certificate")
+	assert.Equal(t, int32(1), connections.Load())
+}
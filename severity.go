@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// severityLevels maps level names usable as mapping keys to severity numbers.
+var severityLevels = map[string]plog.SeverityNumber{
+	"trace":  plog.SeverityNumberTrace,
+	"trace2": plog.SeverityNumberTrace2,
+	"trace3": plog.SeverityNumberTrace3,
+	"trace4": plog.SeverityNumberTrace4,
+	"debug":  plog.SeverityNumberDebug,
+	"debug2": plog.SeverityNumberDebug2,
+	"debug3": plog.SeverityNumberDebug3,
+	"debug4": plog.SeverityNumberDebug4,
+	"info":   plog.SeverityNumberInfo,
+	"info2":  plog.SeverityNumberInfo2,
+	"info3":  plog.SeverityNumberInfo3,
+	"info4":  plog.SeverityNumberInfo4,
+	"warn":   plog.SeverityNumberWarn,
+	"warn2":  plog.SeverityNumberWarn2,
+	"warn3":  plog.SeverityNumberWarn3,
+	"warn4":  plog.SeverityNumberWarn4,
+	"error":  plog.SeverityNumberError,
+	"error2": plog.SeverityNumberError2,
+	"error3": plog.SeverityNumberError3,
+	"error4": plog.SeverityNumberError4,
+	"fatal":  plog.SeverityNumberFatal,
+	"fatal2": plog.SeverityNumberFatal2,
+	"fatal3": plog.SeverityNumberFatal3,
+	"fatal4": plog.SeverityNumberFatal4,
+}
+
+// severityRange maps an inclusive numeric range to a severity number.
+type severityRange struct {
+	min      float64
+	max      float64
+	severity plog.SeverityNumber
+}
+
+// severityMapper resolves source severity values to severity numbers.
+type severityMapper struct {
+	exact  map[string]plog.SeverityNumber
+	ranges []severityRange
+}
+
+// newSeverityMapper compiles a severity mapping table from config.
+func newSeverityMapper(mapping map[string][]interface{}) (*severityMapper, error) {
+	m := &severityMapper{exact: map[string]plog.SeverityNumber{}}
+
+	for level, values := range mapping {
+		severity, ok := severityLevels[strings.ToLower(level)]
+		if !ok {
+			return nil, fmt.Errorf("unknown severity level %q", level)
+		}
+
+		for _, value := range values {
+			if bounds, ok := value.(map[string]interface{}); ok {
+				rng, err := parseSeverityRange(bounds)
+				if err != nil {
+					return nil, fmt.Errorf("level %q: %w", level, err)
+				}
+				rng.severity = severity
+				m.ranges = append(m.ranges, rng)
+				continue
+			}
+
+			key := severityKey(value)
+			if existing, ok := m.exact[key]; ok && existing != severity {
+				return nil, fmt.Errorf("value %q is mapped to more than one level", key)
+			}
+			m.exact[key] = severity
+		}
+	}
+
+	// Keep overlapping ranges deterministic regardless of map iteration order
+	sort.Slice(m.ranges, func(i, j int) bool {
+		if m.ranges[i].min != m.ranges[j].min {
+			return m.ranges[i].min < m.ranges[j].min
+		}
+		return m.ranges[i].max < m.ranges[j].max
+	})
+
+	return m, nil
+}
+
+// parseSeverityRange parses a {min, max} mapping entry.
+func parseSeverityRange(bounds map[string]interface{}) (severityRange, error) {
+	minVal, minOK := toFloat(bounds["min"])
+	maxVal, maxOK := toFloat(bounds["max"])
+	if !minOK || !maxOK {
+		return severityRange{}, fmt.Errorf("range must have numeric min and max, got %v", bounds)
+	}
+	if minVal > maxVal {
+		return severityRange{}, fmt.Errorf("range min %v is greater than max %v", minVal, maxVal)
+	}
+
+	return severityRange{min: minVal, max: maxVal}, nil
+}
+
+// lookup returns the severity number for a source value. Values not in the
+// mapping are matched against the standard level names.
+func (m *severityMapper) lookup(raw interface{}) (plog.SeverityNumber, bool) {
+	key := severityKey(raw)
+
+	if m != nil {
+		if severity, ok := m.exact[key]; ok {
+			return severity, true
+		}
+
+		if num, ok := toFloat(raw); ok {
+			for _, rng := range m.ranges {
+				if num >= rng.min && num <= rng.max {
+					return rng.severity, true
+				}
+			}
+		}
+	}
+
+	if key == "warning" {
+		key = "warn"
+	}
+	severity, ok := severityLevels[key]
+	return severity, ok
+}
+
+// severityKey normalizes a source value for exact matching.
+func severityKey(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strings.ToLower(strings.TrimSpace(v))
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return strconv.FormatFloat(f, 'f', -1, 64)
+		}
+		return string(v)
+	default:
+		return strings.ToLower(fmt.Sprintf("%v", v))
+	}
+}
+
+// toFloat converts numeric values and numeric strings to float64.
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestSeverityMapper_Lookup(t *testing.T) {
+	mapper, err := newSeverityMapper(map[string][]interface{}{
+		"error": {"E", "crit", 50, map[string]interface{}{"min": 500, "max": 599}},
+		"warn":  {"W", 40, map[string]interface{}{"min": 400, "max": 499}},
+		"info2": {"notice"},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		raw    interface{}
+		want   plog.SeverityNumber
+		wantOK bool
+	}{
+		{raw: "E", want: plog.SeverityNumberError, wantOK: true},
+		{raw: "Crit", want: plog.SeverityNumberError, wantOK: true},
+		{raw: float64(50), want: plog.SeverityNumberError, wantOK: true},
+		{raw: float64(503), want: plog.SeverityNumberError, wantOK: true},
+		{raw: "429", want: plog.SeverityNumberWarn, wantOK: true},
+		{raw: float64(40), want: plog.SeverityNumberWarn, wantOK: true},
+		{raw: "notice", want: plog.SeverityNumberInfo2, wantOK: true},
+		{raw: "WARNING", want: plog.SeverityNumberWarn, wantOK: true},
+		{raw: "debug", want: plog.SeverityNumberDebug, wantOK: true},
+		{raw: float64(200), wantOK: false},
+		{raw: "verbose", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := mapper.lookup(tt.raw)
+		assert.Equal(t, tt.wantOK, ok, "lookup(%v)", tt.raw)
+		if tt.wantOK {
+			assert.Equal(t, tt.want, got, "lookup(%v)", tt.raw)
+		}
+	}
+}
+
+func TestNewSeverityMapper_Invalid(t *testing.T) {
+	tests := map[string]map[string][]interface{}{
+		"unknown level":  {"critical": {"C"}},
+		"duplicate":      {"error": {"x"}, "warn": {"X"}},
+		"range no max":   {"error": {map[string]interface{}{"min": 500}}},
+		"range reversed": {"error": {map[string]interface{}{"min": 599, "max": 500}}},
+	}
+
+	for name, mapping := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := newSeverityMapper(mapping)
+			require.Error(t, err)
+		})
+	}
+}
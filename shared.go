@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// receivers holds the receivers shared between the logs and metrics
+// pipelines of the same config, so both signals come from one poll loop.
+var receivers = &sharedReceivers{receivers: map[*Config]*sharedReceiver{}}
+
+// sharedReceiver is a receiver used by several pipelines. It is started by
+// the first pipeline and shut down by the first pipeline to stop, after which
+// it is forgotten.
+type sharedReceiver struct {
+	*logsReceiver
+
+	startOnce    sync.Once
+	startErr     error
+	shutdownOnce sync.Once
+	shutdownErr  error
+	remove       func()
+}
+
+// Start starts the receiver once.
+func (s *sharedReceiver) Start(ctx context.Context, host component.Host) error {
+	s.startOnce.Do(func() {
+		s.startErr = s.logsReceiver.Start(ctx, host)
+	})
+	return s.startErr
+}
+
+// Shutdown stops the receiver once and forgets it.
+func (s *sharedReceiver) Shutdown(ctx context.Context) error {
+	s.shutdownOnce.Do(func() {
+		s.shutdownErr = s.logsReceiver.Shutdown(ctx)
+		s.remove()
+	})
+	return s.shutdownErr
+}
+
+// sharedReceivers maps configs to their shared receiver.
+type sharedReceivers struct {
+	mu        sync.Mutex
+	receivers map[*Config]*sharedReceiver
+}
+
+// getOrCreate returns the receiver of the config, creating it on first use.
+func (m *sharedReceivers) getOrCreate(cfg *Config, settings receiver.Settings) *sharedReceiver {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if shared, ok := m.receivers[cfg]; ok {
+		return shared
+	}
+
+	shared := &sharedReceiver{
+		logsReceiver: newLogsReceiver(cfg, settings, nil),
+		remove: func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			delete(m.receivers, cfg)
+		},
+	}
+	m.receivers[cfg] = shared
+
+	return shared
+}
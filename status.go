@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+)
+
+// httpStatusError is an error response from a target.
+type httpStatusError struct {
+	statusCode int
+	status     string
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("HTTP error: %d %s", e.statusCode, e.status)
+}
+
+// requestError is a failure to build a target's request, which repeats on
+// every poll until the configuration is fixed.
+type requestError struct {
+	err error
+}
+
+func (e *requestError) Error() string {
+	return fmt.Sprintf("failed to create request: %v", e.err)
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+// targetStatus is the health of a single target.
+type targetStatus struct {
+	status       componentstatus.Status
+	err          error
+	authFailures int
+}
+
+// statusReporter derives the receiver's component status from the outcome
+// of each target's polls and reports it to the host whenever it changes.
+// The receiver is OK when all targets are, and otherwise takes the most
+// severe status of its targets. A permanent error is final in the
+// collector's status model, so it is only reported for requests that cannot
+// be built and for credentials rejected authFailureThreshold polls in a row,
+// and nothing is reported after it. A nil statusReporter reports nothing.
+type statusReporter struct {
+	host                 component.Host
+	authFailureThreshold int
+
+	mu      sync.Mutex
+	targets map[*targetConfig]*targetStatus
+	current componentstatus.Status
+}
+
+// newStatusReporter creates a status reporter for the host. The receiver is
+// assumed to be OK until a poll fails. An authFailureThreshold of 0 keeps
+// rejected credentials recoverable.
+func newStatusReporter(host component.Host, authFailureThreshold int) *statusReporter {
+	return &statusReporter{
+		host:                 host,
+		authFailureThreshold: authFailureThreshold,
+		targets:              map[*targetConfig]*targetStatus{},
+		current:              componentstatus.StatusOK,
+	}
+}
+
+// record updates the status of the target with the outcome of a poll.
+func (s *statusReporter) record(target *targetConfig, pollErr error) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ts, ok := s.targets[target]
+	if !ok {
+		ts = &targetStatus{}
+		s.targets[target] = ts
+	}
+
+	// Rejected credentials only become permanent once they persist, as an auth
+	// extension may refresh them in the meantime
+	var statusErr *httpStatusError
+	var reqErr *requestError
+	switch {
+	case pollErr == nil:
+		ts.status, ts.err, ts.authFailures = componentstatus.StatusOK, nil, 0
+	case errors.As(pollErr, &reqErr):
+		ts.status, ts.err = componentstatus.StatusPermanentError, pollErr
+	case errors.As(pollErr, &statusErr) && (statusErr.statusCode == http.StatusUnauthorized || statusErr.statusCode == http.StatusForbidden):
+		ts.authFailures++
+		ts.status, ts.err = componentstatus.StatusRecoverableError, pollErr
+		if s.authFailureThreshold > 0 && ts.authFailures >= s.authFailureThreshold {
+			ts.status = componentstatus.StatusPermanentError
+		}
+	default:
+		ts.status, ts.err, ts.authFailures = componentstatus.StatusRecoverableError, pollErr, 0
+	}
+
+	status, err := componentstatus.StatusOK, error(nil)
+	for t, candidate := range s.targets {
+		if severity(candidate.status) > severity(status) {
+			status, err = candidate.status, fmt.Errorf("target %s: %w", t.Endpoint, candidate.err)
+		}
+	}
+
+	if status == s.current || s.current == componentstatus.StatusPermanentError {
+		return
+	}
+	s.current = status
+
+	switch status {
+	case componentstatus.StatusPermanentError:
+		componentstatus.ReportStatus(s.host, componentstatus.NewPermanentErrorEvent(err))
+	case componentstatus.StatusRecoverableError:
+		componentstatus.ReportStatus(s.host, componentstatus.NewRecoverableErrorEvent(err))
+	default:
+		componentstatus.ReportStatus(s.host, componentstatus.NewEvent(componentstatus.StatusOK))
+	}
+}
+
+// severity orders the statuses a target can have.
+func severity(status componentstatus.Status) int {
+	switch status {
+	case componentstatus.StatusPermanentError:
+		return 2
+	case componentstatus.StatusRecoverableError:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+// statusHost is a component.Host that records reported status events.
+type statusHost struct {
+	extensionsHost
+
+	mu     sync.Mutex
+	events []*componentstatus.Event
+}
+
+func (h *statusHost) Report(event *componentstatus.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, event)
+}
+
+func (h *statusHost) statuses() []componentstatus.Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	statuses := make([]componentstatus.Status, 0, len(h.events))
+	for _, event := range h.events {
+		statuses = append(statuses, event.Status())
+	}
+	return statuses
+}
+
+func TestStatusReporter(t *testing.T) {
+	host := &statusHost{}
+	s := newStatusReporter(host, 3)
+	first := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: "http://first"}}
+	second := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: "http://second"}}
+	unavailable := &httpStatusError{statusCode: http.StatusServiceUnavailable, status: "503 Service Unavailable"}
+	unauthorized := &httpStatusError{statusCode: http.StatusUnauthorized, status: "401 Unauthorized"}
+
+	// an OK poll does not change the initial status
+	s.record(first, nil)
+	assert.Empty(t, host.statuses())
+
+	s.record(first, unavailable)
+	s.record(second, nil)
+	s.record(first, nil)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, host.statuses())
+
+	// an authorization failure that clears before the threshold stays
+	// recoverable, and another error resets the count
+	host.events = nil
+	s.record(second, unauthorized)
+	s.record(second, unauthorized)
+	s.record(second, unavailable)
+	s.record(second, unauthorized)
+	s.record(second, unauthorized)
+	s.record(second, nil)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, host.statuses())
+	require.ErrorContains(t, host.events[0].Err(), "target http://second")
+
+	// one that persists for the threshold fails permanently, and nothing is
+	// reported after the final status
+	host.events = nil
+	for i := 0; i < 3; i++ {
+		s.record(second, unauthorized)
+	}
+	s.record(second, nil)
+	s.record(first, unavailable)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusPermanentError}, host.statuses())
+	require.ErrorContains(t, host.events[1].Err(), "401 Unauthorized")
+
+	var disabled *statusReporter
+	disabled.record(first, unavailable)
+}
+
+func TestStatusReporter_RequestError(t *testing.T) {
+	host := &statusHost{}
+	s := newStatusReporter(host, 3)
+	target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: "http://first"}}
+
+	// a request that cannot be built fails permanently at once
+	s.record(target, &requestError{err: errors.New("template: missing key")})
+	s.record(target, nil)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusPermanentError}, host.statuses())
+	require.ErrorContains(t, host.events[0].Err(), "target http://first")
+}
+
+func TestStatusReporter_AuthFailureThresholdDisabled(t *testing.T) {
+	host := &statusHost{}
+	s := newStatusReporter(host, 0)
+	target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: "http://first"}}
+	forbidden := &httpStatusError{statusCode: http.StatusForbidden, status: "403 Forbidden"}
+
+	for i := 0; i < 10; i++ {
+		s.record(target, forbidden)
+	}
+	s.record(target, nil)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, host.statuses())
+}
+
+func TestLogsReceiver_ReportsStatus(t *testing.T) {
+	var healthy atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{
+		ClientConfig:       confighttp.ClientConfig{Endpoint: srv.URL},
+		CollectionInterval: 10 * time.Millisecond,
+	}
+	require.NoError(t, target.Validate())
+
+	host := &statusHost{}
+	r := newLogsReceiver(&Config{CollectionInterval: time.Hour, Targets: []*targetConfig{target}}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), &testLogsSink{})
+	require.NoError(t, r.Start(context.Background(), host))
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	require.Eventually(t, func() bool { return len(host.statuses()) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, componentstatus.StatusRecoverableError, host.statuses()[0])
+
+	healthy.Store(true)
+	require.Eventually(t, func() bool { return len(host.statuses()) == 2 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, componentstatus.StatusOK, host.statuses()[1])
+}
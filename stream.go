@@ -0,0 +1,363 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+// jsonStream walks a JSON document token by token. Records at the records
+// path are decoded one at a time and handed to onRecord, so only a single
+// record is held in memory. Outside the records, only the values related to
+// the kept paths (envelope attributes, pagination fields) are decoded; they
+// are collected into doc, which mirrors the layout of the response.
+type jsonStream struct {
+	dec         *json.Decoder
+	recordsPath []string
+	keep        [][]string
+	onRecord    func(record interface{}) error
+
+	doc   interface{}
+	found bool
+}
+
+// newJSONStream creates a stream over r emitting the records at recordsPath,
+// which is empty for the document root.
+func newJSONStream(r io.Reader, recordsPath string, keep []string, onRecord func(record interface{}) error) *jsonStream {
+	s := &jsonStream{
+		dec:         json.NewDecoder(r),
+		recordsPath: splitPath(recordsPath),
+		onRecord:    onRecord,
+	}
+	s.dec.UseNumber()
+
+	for _, path := range keep {
+		if path != "" {
+			s.keep = append(s.keep, splitPath(path))
+		}
+	}
+
+	return s
+}
+
+// splitPath splits a dot-separated path into its keys.
+func splitPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, ".")
+}
+
+// run walks the whole document. It reports whether the records path was found.
+func (s *jsonStream) run() (bool, error) {
+	if err := s.walk(nil); err != nil {
+		return false, err
+	}
+
+	if _, err := s.dec.Token(); !errors.Is(err, io.EOF) {
+		return false, errors.New("unexpected data after JSON document")
+	}
+
+	return s.found, nil
+}
+
+// walk consumes the value at path.
+func (s *jsonStream) walk(path []string) error {
+	if slices.Equal(path, s.recordsPath) {
+		s.found = true
+		return s.walkRecords()
+	}
+
+	token, err := s.dec.Token()
+	if err != nil {
+		return err
+	}
+
+	// Values across an array would have to be aggregated, which needs the whole array
+	if token == json.Delim('[') && s.onTheWay(path) {
+		return fmt.Errorf("cannot stream through the array at %q: records_path and envelope paths must lead through objects only", strings.Join(path, "."))
+	}
+
+	// Objects on the way to the records or a kept path are descended into key by key
+	if token == json.Delim('{') && s.onTheWay(path) {
+		for s.dec.More() {
+			keyToken, err := s.dec.Token()
+			if err != nil {
+				return err
+			}
+			key, ok := keyToken.(string)
+			if !ok {
+				return fmt.Errorf("unexpected object key %v", keyToken)
+			}
+			if err := s.walk(append(slices.Clip(path), key)); err != nil {
+				return err
+			}
+		}
+		_, err := s.dec.Token()
+		return err
+	}
+
+	if s.kept(path) {
+		value, err := s.valueFrom(token)
+		if err != nil {
+			return err
+		}
+		s.store(path, value)
+		return nil
+	}
+
+	return s.skipFrom(token)
+}
+
+// walkRecords emits the elements of an array one by one, or any other value as a single record.
+func (s *jsonStream) walkRecords() error {
+	token, err := s.dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if token != json.Delim('[') {
+		record, err := s.valueFrom(token)
+		if err != nil {
+			return err
+		}
+		return s.onRecord(record)
+	}
+
+	for s.dec.More() {
+		var record interface{}
+		if err := s.dec.Decode(&record); err != nil {
+			return err
+		}
+		if err := s.onRecord(record); err != nil {
+			return err
+		}
+	}
+
+	_, err = s.dec.Token()
+	return err
+}
+
+// onTheWay reports whether path leads to the records or to a value nested in a kept path.
+func (s *jsonStream) onTheWay(path []string) bool {
+	if isPrefix(path, s.recordsPath) {
+		return true
+	}
+	for _, keep := range s.keep {
+		if len(path) < len(keep) && isPrefix(path, keep) {
+			return true
+		}
+	}
+	return false
+}
+
+// kept reports whether the value at path is, or lies within, a kept path.
+func (s *jsonStream) kept(path []string) bool {
+	for _, keep := range s.keep {
+		if isPrefix(keep, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// valueFrom decodes the value starting with token.
+func (s *jsonStream) valueFrom(token json.Token) (interface{}, error) {
+	switch token {
+	case json.Delim('{'):
+		object := map[string]interface{}{}
+		for s.dec.More() {
+			keyToken, err := s.dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, ok := keyToken.(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected object key %v", keyToken)
+			}
+			var value interface{}
+			if err := s.dec.Decode(&value); err != nil {
+				return nil, err
+			}
+			object[key] = value
+		}
+		_, err := s.dec.Token()
+		return object, err
+
+	case json.Delim('['):
+		var array []interface{}
+		for s.dec.More() {
+			var value interface{}
+			if err := s.dec.Decode(&value); err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err := s.dec.Token()
+		return array, err
+
+	default:
+		return token, nil
+	}
+}
+
+// skipFrom consumes the rest of the value starting with token without decoding it.
+func (s *jsonStream) skipFrom(token json.Token) error {
+	depth := 0
+	for {
+		if delim, ok := token.(json.Delim); ok {
+			switch delim {
+			case '{', '[':
+				depth++
+			default:
+				depth--
+			}
+		}
+
+		if depth == 0 {
+			return nil
+		}
+
+		var err error
+		if token, err = s.dec.Token(); err != nil {
+			return err
+		}
+	}
+}
+
+// store places value at path in the collected document.
+func (s *jsonStream) store(path []string, value interface{}) {
+	if len(path) == 0 {
+		s.doc = value
+		return
+	}
+
+	if _, ok := s.doc.(map[string]interface{}); !ok {
+		s.doc = map[string]interface{}{}
+	}
+
+	node := s.doc.(map[string]interface{})
+	for _, key := range path[:len(path)-1] {
+		child, ok := node[key].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			node[key] = child
+		}
+		node = child
+	}
+	node[path[len(path)-1]] = value
+}
+
+// isPrefix reports whether prefix is a prefix of path.
+func isPrefix(prefix, path []string) bool {
+	return len(prefix) <= len(path) && slices.Equal(prefix, path[:len(prefix)])
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+// streamPage decodes a JSON response incrementally and passes its records to
+// the pipeline in batches of streaming.batch_size, so memory use does not
+// grow with the size of the response. Envelope attributes are resolved when
+// the first record is reached; an envelope field that only follows the
+// records fails the poll instead of being silently left out.
+func (r *logsReceiver) streamPage(ctx context.Context, resp *http.Response, reader io.Reader, target *targetConfig, pollTime time.Time, pager *paginator, seen *seenFilter) (page, error) {
+	body := &countingReader{r: reader}
+	var result page
+
+	var (
+		batch      plog.Logs
+		scopeLogs  plog.ScopeLogs
+		batchSize  int
+		envelope   map[string]string
+		marks      recordMarks
+		stream     *jsonStream
+		consumeErr error
+	)
+
+	flush := func() error {
+		if batchSize == 0 {
+			return nil
+		}
+		seen.filter(batch)
+		batchSize = batch.LogRecordCount()
+		result.records += batchSize
+		result.latest = marks.latest
+		batchSize = 0
+		consumeErr = r.consumeLogs(ctx, resp, target, formatJSON, batch)
+		return consumeErr
+	}
+
+	keep := append(slices.Collect(maps.Values(target.EnvelopeAttributes)), pager.bodyPaths()...)
+	stream = newJSONStream(body, target.RecordsPath, keep, func(record interface{}) error {
+		if result.records+batchSize == 0 {
+			envelope = r.extractEnvelopeAttributes(stream.doc, target)
+		}
+		if batchSize == 0 {
+			batch = plog.NewLogs()
+			scopeLogs = appendTargetScopeLogs(batch, target)
+		}
+
+		r.addLogRecord(scopeLogs, record, envelope, pollTime, target, &marks)
+		batchSize++
+
+		if batchSize >= target.Streaming.BatchSize {
+			return flush()
+		}
+		return nil
+	})
+
+	found, err := stream.run()
+	result.bytes = body.n
+	if consumeErr != nil {
+		return result, consumeErr
+	}
+	if err != nil {
+		r.telemetry.recordParseError(ctx, target)
+		return result, fmt.Errorf("failed to parse logs: %w", err)
+	}
+
+	if err := flush(); err != nil {
+		return result, err
+	}
+
+	if result.records > 0 {
+		if final := r.extractEnvelopeAttributes(stream.doc, target); !maps.Equal(envelope, final) {
+			r.telemetry.recordParseError(ctx, target)
+			return result, errors.New("failed to parse logs: envelope_attributes fields must precede records_path in the response when streaming")
+		}
+	}
+
+	if !found {
+		r.logger.Debug("Records path not found in response",
+			zap.String("endpoint", target.Endpoint),
+			zap.String("records_path", target.RecordsPath))
+	}
+
+	result.doc = stream.doc
+	result.lastID = marks.lastID
+
+	return result, nil
+}
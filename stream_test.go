@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestJSONStream(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		recordsPath string
+		keep        []string
+		wantRecords []interface{}
+		wantDoc     interface{}
+		wantFound   bool
+		wantErr     bool
+	}{
+		{
+			name:        "root array",
+			body:        `[{"a":1},{"a":2}]`,
+			wantRecords: []interface{}{map[string]interface{}{"a": json.Number("1")}, map[string]interface{}{"a": json.Number("2")}},
+			wantFound:   true,
+		},
+		{
+			name:        "nested records with kept values around them",
+			body:        `{"meta":{"total":2,"skip":{"x":[1,2]}},"data":{"items":[{"a":1},{"a":2}],"next":"/p2"},"ignored":[{"b":1}]}`,
+			recordsPath: "data.items",
+			keep:        []string{"meta.total", "data.next"},
+			wantRecords: []interface{}{map[string]interface{}{"a": json.Number("1")}, map[string]interface{}{"a": json.Number("2")}},
+			wantDoc:     map[string]interface{}{"meta": map[string]interface{}{"total": json.Number("2")}, "data": map[string]interface{}{"next": "/p2"}},
+			wantFound:   true,
+		},
+		{
+			name:        "object becomes a single record",
+			body:        `{"result":{"msg":"one","tags":["x"]}}`,
+			recordsPath: "result",
+			wantRecords: []interface{}{map[string]interface{}{"msg": "one", "tags": []interface{}{"x"}}},
+			wantFound:   true,
+		},
+		{
+			name:        "records path not found",
+			body:        `{"data":{"list":[1,2]},"meta":{"count":0}}`,
+			recordsPath: "data.items",
+			keep:        []string{"meta"},
+			wantDoc:     map[string]interface{}{"meta": map[string]interface{}{"count": json.Number("0")}},
+		},
+		{
+			name:        "array along the records path",
+			body:        `{"data":[{"items":[{"a":1}]}]}`,
+			recordsPath: "data.items",
+			wantErr:     true,
+		},
+		{
+			name:        "array along a kept path",
+			body:        `{"meta":[{"total":1}],"items":[]}`,
+			recordsPath: "items",
+			keep:        []string{"meta.total"},
+			wantErr:     true,
+		},
+		{
+			name:    "invalid JSON",
+			body:    `[{"a":1},{"a":`,
+			wantErr: true,
+		},
+		{
+			name:    "trailing data",
+			body:    `[] []`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var records []interface{}
+			stream := newJSONStream(strings.NewReader(tt.body), tt.recordsPath, tt.keep, func(record interface{}) error {
+				records = append(records, record)
+				return nil
+			})
+
+			found, err := stream.run()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.wantRecords, records)
+			assert.Equal(t, tt.wantDoc, stream.doc)
+		})
+	}
+}
+
+func TestLogsReceiver_StreamingBatches(t *testing.T) {
+	// two pages; the next page link follows the records array
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		first, count, next := 0, 2500, `"/logs?page=2"`
+		if r.URL.Query().Get("page") == "2" {
+			first, count, next = 2500, 10, `null`
+		}
+
+		var sb strings.Builder
+		sb.WriteString(`{"source":"audit","data":{"items":[`)
+		for i := first; i < first+count; i++ {
+			if i > first {
+				sb.WriteString(",")
+			}
+			fmt.Fprintf(&sb, `{"id":%d,"msg":"event %d"}`, i, i)
+		}
+		fmt.Fprintf(&sb, `],"next":%s}}`, next)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(sb.String()))
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{
+		ClientConfig:       confighttp.ClientConfig{Endpoint: srv.URL + "/logs"},
+		RecordsPath:        "data.items",
+		EnvelopeAttributes: map[string]string{"source": "source"},
+		IDField:            "id",
+		Pagination:         &paginationConfig{Mode: paginationModeNextURL, NextURLPath: "data.next"},
+		Streaming:          &streamingConfig{},
+	}
+	require.NoError(t, target.Validate())
+	assert.Equal(t, 1000, target.Streaming.BatchSize)
+
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), sink)
+	require.NoError(t, r.pollTarget(context.Background(), target))
+
+	batches := sink.AllLogs()
+	var sizes []int
+	for _, logs := range batches {
+		sizes = append(sizes, logs.LogRecordCount())
+	}
+	assert.Equal(t, []int{1000, 1000, 500, 10}, sizes)
+
+	record := batches[1].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	source, _ := record.Attributes().Get("source")
+	assert.Equal(t, "audit", source.Str())
+	id, _ := record.Body().Map().Get("id")
+	assert.Equal(t, int64(1000), id.Int())
+
+	state, err := r.state.get(context.Background(), stateKey(target))
+	require.NoError(t, err)
+	assert.Equal(t, "2509", state.LastID)
+}
+
+func TestLogsReceiver_StreamingMatchesBuffered(t *testing.T) {
+	const payload = `{"page":{"n":1},"records":[{"ts":"2025-10-15T10:00:00Z","level":"warn","user":{"email":"a@example.com"}},{"ts":"2025-10-15T11:00:00Z","level":"error","user":{"email":"b@example.com"}}]}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer srv.Close()
+
+	newTarget := func(streaming *streamingConfig) *targetConfig {
+		target := &targetConfig{
+			ClientConfig:       confighttp.ClientConfig{Endpoint: srv.URL},
+			RecordsPath:        "records",
+			EnvelopeAttributes: map[string]string{"page": "page.n"},
+			Labels:             map[string]string{"email": "user.email"},
+			Timestamp:          &timestampConfig{Field: "ts"},
+			Severity:           &severityConfig{Field: "level"},
+			Streaming:          streaming,
+		}
+		require.NoError(t, target.Validate())
+		return target
+	}
+
+	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
+	buffered, streamed := &testLogsSink{}, &testLogsSink{}
+	require.NoError(t, newLogsReceiver(&Config{}, settings, buffered).pollTarget(context.Background(), newTarget(nil)))
+	require.NoError(t, newLogsReceiver(&Config{}, settings, streamed).pollTarget(context.Background(), newTarget(&streamingConfig{})))
+
+	want := buffered.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	got := streamed.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, want.Len(), got.Len())
+	for i := 0; i < want.Len(); i++ {
+		assert.Equal(t, want.At(i).Timestamp(), got.At(i).Timestamp())
+		assert.Equal(t, want.At(i).SeverityNumber(), got.At(i).SeverityNumber())
+		assert.Equal(t, want.At(i).Attributes().AsRaw(), got.At(i).Attributes().AsRaw())
+		assert.Equal(t, want.At(i).Body().AsRaw(), got.At(i).Body().AsRaw())
+	}
+}
+
+func TestLogsReceiver_StreamingEnvelopeAfterRecords(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[{"msg":"a"},{"msg":"b"}],"source":"audit"}`))
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{
+		ClientConfig:       confighttp.ClientConfig{Endpoint: srv.URL},
+		RecordsPath:        "items",
+		EnvelopeAttributes: map[string]string{"source": "source"},
+		Streaming:          &streamingConfig{},
+	}
+	require.NoError(t, target.Validate())
+
+	// the buffered parser would see the field, so the streamed records must not silently lack it
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), &testLogsSink{})
+	require.ErrorContains(t, r.pollTarget(context.Background(), target), "envelope_attributes fields must precede records_path")
+}
+
+func TestStreamingConfig_EnvelopeOverlapsRecords(t *testing.T) {
+	for _, tt := range []struct {
+		recordsPath string
+		envelope    string
+		wantErr     bool
+	}{
+		{recordsPath: "data.items", envelope: "meta.source"},
+		{recordsPath: "data.items", envelope: "data.source"},
+		{recordsPath: "data.items", envelope: "data", wantErr: true},
+		{recordsPath: "data.items", envelope: "data.items.source", wantErr: true},
+		{recordsPath: "", envelope: "source", wantErr: true},
+	} {
+		target := &targetConfig{
+			ClientConfig:       confighttp.ClientConfig{Endpoint: "https://example.com/export"},
+			RecordsPath:        tt.recordsPath,
+			EnvelopeAttributes: map[string]string{"source": tt.envelope},
+			Streaming:          &streamingConfig{},
+		}
+		if tt.wantErr {
+			assert.Error(t, target.Validate(), tt.envelope)
+		} else {
+			assert.NoError(t, target.Validate(), tt.envelope)
+		}
+	}
+}
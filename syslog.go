@@ -0,0 +1,271 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// syslogSeverities maps syslog severity codes to their keywords and severity numbers.
+var syslogSeverities = [8]struct {
+	text   string
+	number plog.SeverityNumber
+}{
+	{"emerg", plog.SeverityNumberFatal3},
+	{"alert", plog.SeverityNumberFatal2},
+	{"crit", plog.SeverityNumberFatal},
+	{"err", plog.SeverityNumberError},
+	{"warning", plog.SeverityNumberWarn},
+	{"notice", plog.SeverityNumberInfo2},
+	{"info", plog.SeverityNumberInfo},
+	{"debug", plog.SeverityNumberDebug},
+}
+
+// rfc3164Tag matches the TAG[PID]: part of an RFC 3164 message.
+var rfc3164Tag = regexp.MustCompile(`^([^:\[\s]+)(?:\[([^\]]*)\])?:\s?`)
+
+// syslogMessage is a parsed syslog line. Fields missing from the line are empty.
+type syslogMessage struct {
+	// PRI value, or -1 when the line has none
+	priority int
+
+	timestamp      time.Time
+	hostname       string
+	appname        string
+	procid         string
+	msgid          string
+	structuredData map[string]map[string]string
+	message        string
+}
+
+// parseSyslog parses an RFC 5424 or RFC 3164 line. Timestamps of RFC 3164
+// carry neither a year nor a time zone; they are taken to be in loc and in
+// the year that places them closest before now.
+func parseSyslog(line string, now time.Time, loc *time.Location) (*syslogMessage, error) {
+	msg := &syslogMessage{priority: -1}
+
+	if strings.HasPrefix(line, "<") {
+		end := strings.IndexByte(line, '>')
+		if end < 2 || end > 4 {
+			return nil, errors.New("invalid PRI")
+		}
+		priority, err := strconv.Atoi(line[1:end])
+		if err != nil || priority < 0 || priority > 191 {
+			return nil, fmt.Errorf("invalid PRI %q", line[1:end])
+		}
+		msg.priority = priority
+		line = line[end+1:]
+	}
+
+	if strings.HasPrefix(line, "1 ") {
+		return msg, parseRFC5424(msg, line[2:])
+	}
+	return msg, parseRFC3164(msg, line, now, loc)
+}
+
+// parseRFC5424 parses the part of an RFC 5424 line following the version.
+func parseRFC5424(msg *syslogMessage, line string) error {
+	fields := strings.SplitN(line, " ", 6)
+	if len(fields) < 6 {
+		return errors.New("incomplete RFC 5424 header")
+	}
+
+	if fields[0] != "-" {
+		ts, err := time.Parse(time.RFC3339Nano, fields[0])
+		if err != nil {
+			return fmt.Errorf("invalid timestamp: %w", err)
+		}
+		msg.timestamp = ts
+	}
+
+	for i, dest := range []*string{&msg.hostname, &msg.appname, &msg.procid, &msg.msgid} {
+		if fields[i+1] != "-" {
+			*dest = fields[i+1]
+		}
+	}
+
+	rest := fields[5]
+	if rest == "-" || strings.HasPrefix(rest, "- ") {
+		rest = strings.TrimPrefix(rest, "-")
+	} else {
+		var err error
+		if msg.structuredData, rest, err = parseStructuredData(rest); err != nil {
+			return err
+		}
+	}
+
+	msg.message = strings.TrimPrefix(strings.TrimPrefix(rest, " "), "\ufeff")
+	return nil
+}
+
+// parseStructuredData parses the SD-ELEMENTs at the start of s and returns the rest.
+func parseStructuredData(s string) (map[string]map[string]string, string, error) {
+	elements := map[string]map[string]string{}
+
+	for strings.HasPrefix(s, "[") {
+		s = s[1:]
+		end := strings.IndexAny(s, " ]")
+		if end <= 0 {
+			return nil, "", errors.New("invalid structured data ID")
+		}
+		params := map[string]string{}
+		elements[s[:end]] = params
+		s = s[end:]
+
+		for strings.HasPrefix(s, " ") {
+			s = s[1:]
+			eq := strings.Index(s, `="`)
+			if eq <= 0 {
+				return nil, "", errors.New("invalid structured data parameter")
+			}
+			name := s[:eq]
+			s = s[eq+2:]
+
+			// Values escape ", \ and ] with a backslash
+			var value strings.Builder
+			closed := false
+			for i := 0; i < len(s); i++ {
+				if s[i] == '\\' && i+1 < len(s) && strings.IndexByte(`"\]`, s[i+1]) >= 0 {
+					i++
+				} else if s[i] == '"' {
+					s, closed = s[i+1:], true
+					break
+				}
+				value.WriteByte(s[i])
+			}
+			if !closed {
+				return nil, "", errors.New("unterminated structured data value")
+			}
+			params[name] = value.String()
+		}
+
+		if !strings.HasPrefix(s, "]") {
+			return nil, "", errors.New("unterminated structured data element")
+		}
+		s = s[1:]
+	}
+
+	return elements, s, nil
+}
+
+// parseRFC3164 parses an RFC 3164 line following the PRI.
+func parseRFC3164(msg *syslogMessage, line string, now time.Time, loc *time.Location) error {
+	// The timestamp is either the classic "Jan _2 15:04:05" or an RFC 3339 one
+	if len(line) >= len(time.Stamp) {
+		if ts, err := time.ParseInLocation(time.Stamp, line[:len(time.Stamp)], loc); err == nil {
+			now = now.In(loc)
+			ts = time.Date(now.Year(), ts.Month(), ts.Day(), ts.Hour(), ts.Minute(), ts.Second(), 0, loc)
+			// A timestamp from the end of last year read early in the new one
+			if ts.After(now.Add(24 * time.Hour)) {
+				ts = ts.AddDate(-1, 0, 0)
+			}
+			msg.timestamp = ts
+			line = line[len(time.Stamp):]
+		}
+	}
+	if msg.timestamp.IsZero() {
+		field, rest, _ := strings.Cut(line, " ")
+		ts, err := time.Parse(time.RFC3339Nano, field)
+		if err != nil {
+			return errors.New("invalid RFC 3164 timestamp")
+		}
+		msg.timestamp, line = ts, " "+rest
+	}
+
+	if !strings.HasPrefix(line, " ") {
+		return errors.New("missing hostname")
+	}
+	msg.hostname, line, _ = strings.Cut(line[1:], " ")
+
+	if match := rfc3164Tag.FindStringSubmatch(line); match != nil {
+		msg.appname, msg.procid = match[1], match[2]
+		line = line[len(match[0]):]
+	}
+	msg.message = line
+
+	return nil
+}
+
+// parseSyslogLogs parses syslog lines. Lines that are not valid syslog are kept as plain text records.
+func (r *logsReceiver) parseSyslogLogs(body []byte, target *targetConfig, pollTime time.Time, logs plog.Logs) (plog.Logs, error) {
+	scopeLogs := appendTargetScopeLogs(logs, target)
+
+	// As for text, labels are static resource attributes, since there are no fields to extract them from
+	resource := logs.ResourceLogs().At(logs.ResourceLogs().Len() - 1).Resource()
+	for key, value := range target.Labels {
+		resource.Attributes().PutStr(key, value)
+	}
+
+	for _, line := range splitTextEntries(string(body), target.Multiline) {
+		if !r.addSyslogRecord(scopeLogs, line, pollTime, target) {
+			r.addTextRecord(scopeLogs, line, pollTime, target)
+		}
+	}
+
+	return logs, nil
+}
+
+// addSyslogRecord adds a record for a syslog line. It reports false, adding
+// nothing, when the line cannot be parsed.
+func (r *logsReceiver) addSyslogRecord(scopeLogs plog.ScopeLogs, line string, pollTime time.Time, target *targetConfig) bool {
+	loc := time.UTC
+	if target.Syslog != nil && target.Syslog.location != nil {
+		loc = target.Syslog.location
+	}
+
+	msg, err := parseSyslog(line, pollTime, loc)
+	if err != nil {
+		return false
+	}
+
+	logRecord := scopeLogs.LogRecords().AppendEmpty()
+	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(pollTime))
+	if !msg.timestamp.IsZero() {
+		logRecord.SetTimestamp(pcommon.NewTimestampFromTime(msg.timestamp))
+	}
+	logRecord.SetObservedTimestamp(pcommon.NewTimestampFromTime(pollTime))
+
+	if msg.priority >= 0 {
+		severity := syslogSeverities[msg.priority%8]
+		logRecord.SetSeverityText(severity.text)
+		logRecord.SetSeverityNumber(severity.number)
+	} else {
+		logRecord.SetSeverityText(strings.ToUpper(target.LogLevel))
+		logRecord.SetSeverityNumber(r.getSeverityNumber(target.LogLevel))
+	}
+
+	attrs := logRecord.Attributes()
+	for key, value := range map[string]string{
+		"host.name": msg.hostname,
+		"appname":   msg.appname,
+		"procid":    msg.procid,
+		"msgid":     msg.msgid,
+	} {
+		if value != "" {
+			attrs.PutStr(key, value)
+		}
+	}
+
+	if len(msg.structuredData) > 0 {
+		structured := attrs.PutEmptyMap("structured_data")
+		for id, params := range msg.structuredData {
+			element := structured.PutEmptyMap(id)
+			for name, value := range params {
+				element.PutStr(name, value)
+			}
+		}
+	}
+
+	logRecord.Body().SetStr(msg.message)
+
+	return true
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestParseSyslog(t *testing.T) {
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		line    string
+		loc     *time.Location
+		want    *syslogMessage
+		wantErr bool
+	}{
+		{
+			name: "rfc5424 with structured data",
+			line: `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog 1234 ID47 [exampleSDID@32473 iut="3" eventSource="Application" eventID="1011"][examplePriority@32473 class="high \"x\" \]"] An application event`,
+			want: &syslogMessage{
+				priority:  165,
+				timestamp: time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC),
+				hostname:  "mymachine.example.com",
+				appname:   "evntslog",
+				procid:    "1234",
+				msgid:     "ID47",
+				structuredData: map[string]map[string]string{
+					"exampleSDID@32473":     {"iut": "3", "eventSource": "Application", "eventID": "1011"},
+					"examplePriority@32473": {"class": `high "x" ]`},
+				},
+				message: "An application event",
+			},
+		},
+		{
+			name: "rfc5424 with nil values",
+			line: "<34>1 - - su - - - 'su root' failed",
+			want: &syslogMessage{priority: 34, appname: "su", message: "'su root' failed"},
+		},
+		{
+			name: "rfc5424 without message",
+			line: "<34>1 2003-10-11T22:14:15Z host app - - -",
+			want: &syslogMessage{priority: 34, timestamp: time.Date(2003, 10, 11, 22, 14, 15, 0, time.UTC), hostname: "host", appname: "app"},
+		},
+		{
+			name: "rfc3164",
+			line: "<34>Oct 11 22:14:15 mymachine su[230]: 'su root' failed for lonvick on /dev/pts/8",
+			loc:  berlin,
+			want: &syslogMessage{
+				priority:  34,
+				timestamp: time.Date(2024, 10, 11, 22, 14, 15, 0, berlin),
+				hostname:  "mymachine",
+				appname:   "su",
+				procid:    "230",
+				message:   "'su root' failed for lonvick on /dev/pts/8",
+			},
+		},
+		{
+			name: "rfc3164 in the current year without PRI or tag",
+			line: "Jan  2 10:00:00 router link down",
+			want: &syslogMessage{priority: -1, timestamp: time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC), hostname: "router", message: "link down"},
+		},
+		{
+			name: "rfc3164 with rfc3339 timestamp",
+			line: "<13>2025-01-02T11:00:00+01:00 fw kernel: dropped packet",
+			want: &syslogMessage{priority: 13, timestamp: time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC), hostname: "fw", appname: "kernel", message: "dropped packet"},
+		},
+		{name: "invalid PRI", line: "<999>1 - - - - - -", wantErr: true},
+		{name: "not syslog", line: "plain text line", wantErr: true},
+		{name: "unterminated structured data", line: `<1>1 - - - - - [id a="b`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := tt.loc
+			if loc == nil {
+				loc = time.UTC
+			}
+			msg, err := parseSyslog(tt.line, now, loc)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.timestamp.Equal(msg.timestamp), "timestamp %v", msg.timestamp)
+			msg.timestamp = tt.want.timestamp
+			assert.Equal(t, tt.want, msg)
+		})
+	}
+}
+
+func TestLogsReceiver_Syslog(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("<11>1 2025-10-15T10:00:00Z fw01 filterlog 77 BLOCK [meta@1 rule=\"12\"] blocked 10.0.0.1\n" +
+			"<14>Oct 15 10:00:01 fw01 sshd[301]: accepted key\n" +
+			"--- buffer truncated ---\n"))
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}, Format: "syslog", Labels: map[string]string{"site": "dc1"}}
+	require.NoError(t, target.Validate())
+
+	sink := &testLogsSink{}
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), sink)
+	require.NoError(t, r.pollTarget(context.Background(), target))
+
+	// labels are kept on the resource, as for text targets
+	site, _ := sink.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().Get("site")
+	assert.Equal(t, "dc1", site.Str())
+
+	records := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 3, records.Len())
+
+	first := records.At(0)
+	assert.Equal(t, "blocked 10.0.0.1", first.Body().Str())
+	assert.Equal(t, "err", first.SeverityText())
+	assert.Equal(t, plog.SeverityNumberError, first.SeverityNumber())
+	assert.Equal(t, time.Date(2025, 10, 15, 10, 0, 0, 0, time.UTC), first.Timestamp().AsTime())
+	assert.Equal(t, map[string]interface{}{
+		"host.name":       "fw01",
+		"appname":         "filterlog",
+		"procid":          "77",
+		"msgid":           "BLOCK",
+		"structured_data": map[string]interface{}{"meta@1": map[string]interface{}{"rule": "12"}},
+	}, first.Attributes().AsRaw())
+
+	second := records.At(1)
+	assert.Equal(t, "accepted key", second.Body().Str())
+	assert.Equal(t, plog.SeverityNumberInfo, second.SeverityNumber())
+	assert.Equal(t, map[string]interface{}{"host.name": "fw01", "appname": "sshd", "procid": "301"}, second.Attributes().AsRaw())
+
+	// a line that is not syslog is kept as plain text
+	third := records.At(2)
+	assert.Equal(t, "--- buffer truncated ---", third.Body().Str())
+	assert.Equal(t, "INFO", third.SeverityText())
+}
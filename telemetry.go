@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// scopeName is the instrumentation scope of the receiver's own telemetry.
+const scopeName = "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+// Attributes of the per-target metrics
+const (
+	targetAttribute     = "target"
+	statusCodeAttribute = "http.response.status_code"
+)
+
+// receiverTelemetry records the receiver's own metrics. A nil
+// receiverTelemetry records nothing, which is the case before Start.
+type receiverTelemetry struct {
+	obsrecv *receiverhelper.ObsReport
+
+	requestDuration metric.Float64Histogram
+	responses       metric.Int64Counter
+	receivedBytes   metric.Int64Counter
+	records         metric.Int64Counter
+	parseErrors     metric.Int64Counter
+	skippedLines    metric.Int64Counter
+
+	registration metric.Registration
+}
+
+// newReceiverTelemetry creates the receiver's instruments and registers the
+// gauges observed from the receiver's targets.
+func newReceiverTelemetry(r *logsReceiver) (*receiverTelemetry, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             r.settings.ID,
+		Transport:              "http",
+		ReceiverCreateSettings: r.settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	meter := r.settings.MeterProvider.Meter(scopeName)
+	t := &receiverTelemetry{obsrecv: obsrecv}
+	var errs error
+
+	t.requestDuration, err = meter.Float64Histogram("otelcol_logsreceiver_target_request_duration",
+		metric.WithDescription("Duration of HTTP requests to the target, including retried attempts"),
+		metric.WithUnit("s"))
+	errs = errors.Join(errs, err)
+
+	t.responses, err = meter.Int64Counter("otelcol_logsreceiver_target_responses",
+		metric.WithDescription("Number of HTTP responses received from the target by status code"),
+		metric.WithUnit("{response}"))
+	errs = errors.Join(errs, err)
+
+	t.receivedBytes, err = meter.Int64Counter("otelcol_logsreceiver_target_received_bytes",
+		metric.WithDescription("Number of response body bytes received from the target"),
+		metric.WithUnit("By"))
+	errs = errors.Join(errs, err)
+
+	t.records, err = meter.Int64Counter("otelcol_logsreceiver_target_records",
+		metric.WithDescription("Number of log records produced from the target's responses"),
+		metric.WithUnit("{record}"))
+	errs = errors.Join(errs, err)
+
+	t.parseErrors, err = meter.Int64Counter("otelcol_logsreceiver_target_parse_errors",
+		metric.WithDescription("Number of target responses that could not be parsed"),
+		metric.WithUnit("{error}"))
+	errs = errors.Join(errs, err)
+
+	t.skippedLines, err = meter.Int64Counter("otelcol_logsreceiver_target_skipped_lines",
+		metric.WithDescription("Number of malformed lines skipped in the target's line-delimited responses"),
+		metric.WithUnit("{line}"))
+	errs = errors.Join(errs, err)
+
+	lastSuccess, err := meter.Int64ObservableGauge("otelcol_logsreceiver_target_last_success_timestamp",
+		metric.WithDescription("Unix time of the target's last successful poll"),
+		metric.WithUnit("s"))
+	errs = errors.Join(errs, err)
+
+	breakerState, err := meter.Int64ObservableGauge("otelcol_logsreceiver_target_circuit_breaker_state",
+		metric.WithDescription("Circuit breaker state of the target: 0 closed, 1 open, 2 half-open"),
+		metric.WithUnit("{state}"))
+	errs = errors.Join(errs, err)
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	t.registration, err = meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		for _, target := range r.config.Targets {
+			attrs := metric.WithAttributes(attribute.String(targetAttribute, target.Endpoint))
+			if state, ok := r.state.cached(stateKey(target)); ok && !state.LastSuccessTime.IsZero() {
+				observer.ObserveInt64(lastSuccess, state.LastSuccessTime.Unix(), attrs)
+			}
+			if breaker, ok := r.breakers[target]; ok {
+				observer.ObserveInt64(breakerState, int64(breaker.currentState()), attrs)
+			}
+		}
+		return nil
+	}, lastSuccess, breakerState)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// startLogsOp starts an obsreport operation for consuming a page of logs.
+func (t *receiverTelemetry) startLogsOp(ctx context.Context) context.Context {
+	if t == nil {
+		return ctx
+	}
+
+	return t.obsrecv.StartLogsOp(ctx)
+}
+
+// endLogsOp ends an obsreport operation, recording the records as accepted or refused.
+func (t *receiverTelemetry) endLogsOp(ctx context.Context, format string, count int, err error) {
+	if t == nil {
+		return
+	}
+
+	t.obsrecv.EndLogsOp(ctx, format, count, err)
+}
+
+// recordRequest records a single HTTP request attempt. statusCode is 0 when
+// no response was received.
+func (t *receiverTelemetry) recordRequest(ctx context.Context, target *targetConfig, duration time.Duration, statusCode int) {
+	if t == nil {
+		return
+	}
+
+	attrs := attribute.String(targetAttribute, target.Endpoint)
+	t.requestDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(attrs))
+	if statusCode != 0 {
+		t.responses.Add(ctx, 1, metric.WithAttributes(attrs, attribute.Int(statusCodeAttribute, statusCode)))
+	}
+}
+
+// recordPage records the size of a response body and the records parsed from it.
+func (t *receiverTelemetry) recordPage(ctx context.Context, target *targetConfig, bytes, records int) {
+	if t == nil {
+		return
+	}
+
+	attrs := metric.WithAttributes(attribute.String(targetAttribute, target.Endpoint))
+	t.receivedBytes.Add(ctx, int64(bytes), attrs)
+	t.records.Add(ctx, int64(records), attrs)
+}
+
+// recordParseError records a response that could not be parsed.
+func (t *receiverTelemetry) recordParseError(ctx context.Context, target *targetConfig) {
+	if t == nil {
+		return
+	}
+
+	t.parseErrors.Add(ctx, 1, metric.WithAttributes(attribute.String(targetAttribute, target.Endpoint)))
+}
+
+// recordSkippedLines records malformed lines skipped in a response.
+func (t *receiverTelemetry) recordSkippedLines(ctx context.Context, target *targetConfig, lines int) {
+	if t == nil {
+		return
+	}
+
+	t.skippedLines.Add(ctx, int64(lines), metric.WithAttributes(attribute.String(targetAttribute, target.Endpoint)))
+}
+
+// shutdown unregisters the observed gauges.
+func (t *receiverTelemetry) shutdown() error {
+	if t == nil {
+		return nil
+	}
+
+	return t.registration.Unregister()
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestLogsReceiver_Telemetry(t *testing.T) {
+	const payload = `[{"msg":"a"},{"msg":"b"}]`
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer ok.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"msg":`))
+	}))
+	defer broken.Close()
+
+	tel := componenttest.NewTelemetry()
+	defer func() { require.NoError(t, tel.Shutdown(context.Background())) }()
+	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
+	settings.TelemetrySettings = tel.NewTelemetrySettings()
+
+	cfg := &Config{
+		CollectionInterval: time.Hour,
+		Targets: []*targetConfig{
+			{ClientConfig: confighttp.ClientConfig{Endpoint: ok.URL}},
+			{ClientConfig: confighttp.ClientConfig{Endpoint: broken.URL}},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	sink := &testLogsSink{}
+	r := newLogsReceiver(cfg, settings, sink)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+	waitForLogs(t, sink, 2, 2*time.Second)
+	require.Eventually(t, func() bool {
+		_, parseErr := tel.GetMetric("otelcol_logsreceiver_target_parse_errors")
+		_, successErr := tel.GetMetric("otelcol_logsreceiver_target_last_success_timestamp")
+		return parseErr == nil && successErr == nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, int64(2), sumValue(t, tel, "otelcol_receiver_accepted_log_records", attribute.String("receiver", settings.ID.String())))
+	assert.Equal(t, int64(2), sumValue(t, tel, "otelcol_logsreceiver_target_records", attribute.String(targetAttribute, ok.URL)))
+	assert.Equal(t, int64(len(payload)), sumValue(t, tel, "otelcol_logsreceiver_target_received_bytes", attribute.String(targetAttribute, ok.URL)))
+	assert.Equal(t, int64(1), sumValue(t, tel, "otelcol_logsreceiver_target_responses", attribute.String(targetAttribute, ok.URL), attribute.Int(statusCodeAttribute, http.StatusOK)))
+	assert.Equal(t, int64(1), sumValue(t, tel, "otelcol_logsreceiver_target_parse_errors", attribute.String(targetAttribute, broken.URL)))
+
+	m, err := tel.GetMetric("otelcol_logsreceiver_target_request_duration")
+	require.NoError(t, err)
+	histogram, isHistogram := m.Data.(metricdata.Histogram[float64])
+	require.True(t, isHistogram)
+	assert.Len(t, histogram.DataPoints, 2)
+
+	m, err = tel.GetMetric("otelcol_logsreceiver_target_last_success_timestamp")
+	require.NoError(t, err)
+	gauge, isGauge := m.Data.(metricdata.Gauge[int64])
+	require.True(t, isGauge)
+	// only the target that parsed successfully has a last success time
+	require.Len(t, gauge.DataPoints, 1)
+	target, _ := gauge.DataPoints[0].Attributes.Value(targetAttribute)
+	assert.Equal(t, ok.URL, target.AsString())
+	assert.InDelta(t, time.Now().Unix(), gauge.DataPoints[0].Value, 5)
+}
+
+// sumValue returns the value of the sum data point carrying all the given attributes.
+func sumValue(t *testing.T, tel *componenttest.Telemetry, name string, attrs ...attribute.KeyValue) int64 {
+	m, err := tel.GetMetric(name)
+	require.NoError(t, err)
+	sum, ok := m.Data.(metricdata.Sum[int64])
+	require.True(t, ok, name)
+
+	for _, dp := range sum.DataPoints {
+		matches := true
+		for _, attr := range attrs {
+			if v, found := dp.Attributes.Value(attr.Key); !found || v != attr.Value {
+				matches = false
+			}
+		}
+		if matches {
+			return dp.Value
+		}
+	}
+
+	t.Fatalf("no %s data point with attributes %v", name, attrs)
+	return 0
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// templateVars are the variables available when rendering a target's
+// endpoint, headers and body.
+type templateVars struct {
+	// Time of the current poll
+	Now time.Time
+
+	// Time of the previous poll, successful or not
+	LastPollTime time.Time
+
+	// Time of the previous successful poll
+	LastSuccessTime time.Time
+
+	// Start of the window covered by this poll: the last successful poll,
+	// or one collection interval ago on the first poll
+	WindowStart time.Time
+
+	// End of the window covered by this poll, equal to Now
+	WindowEnd time.Time
+
+	// Checkpointed position of the target
+	LastTimestamp time.Time
+	LastCursor    string
+	LastID        string
+}
+
+// newTemplateVars builds the template variables for a poll from the target state.
+func newTemplateVars(pollTime time.Time, state targetState, interval time.Duration) templateVars {
+	windowStart := state.LastSuccessTime
+	if windowStart.IsZero() {
+		windowStart = pollTime.Add(-interval)
+	}
+
+	return templateVars{
+		Now:             pollTime,
+		LastPollTime:    state.LastPollTime,
+		LastSuccessTime: state.LastSuccessTime,
+		WindowStart:     windowStart,
+		WindowEnd:       pollTime,
+		LastTimestamp:   state.LastTimestamp,
+		LastCursor:      state.LastCursor,
+		LastID:          state.LastID,
+	}
+}
+
+// templateFuncs are the functions available in request templates.
+var templateFuncs = template.FuncMap{
+	"format": func(layout string, t time.Time) string {
+		return t.Format(layout)
+	},
+	"unix": func(t time.Time) int64 {
+		return t.Unix()
+	},
+	"unixMilli": func(t time.Time) int64 {
+		return t.UnixMilli()
+	},
+	"utc": func(t time.Time) time.Time {
+		return t.UTC()
+	},
+	"add": func(d string, t time.Time) (time.Time, error) {
+		duration, err := time.ParseDuration(d)
+		if err != nil {
+			return t, err
+		}
+		return t.Add(duration), nil
+	},
+	"env": os.Getenv,
+}
+
+// parseTemplate parses a request template. Text without template actions
+// yields a nil template and is used as is.
+func parseTemplate(name, text string) (*template.Template, error) {
+	if !strings.Contains(text, "{{") {
+		return nil, nil
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s template: %w", name, err)
+	}
+
+	return tmpl, nil
+}
+
+// renderTemplate renders a parsed template with the given variables. A nil
+// template renders text unchanged.
+func renderTemplate(tmpl *template.Template, text string, vars templateVars) (string, error) {
+	if tmpl == nil {
+		return text, nil
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, vars); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", tmpl.Name(), err)
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestNewTemplateVars(t *testing.T) {
+	now := time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	first := newTemplateVars(now, targetState{}, time.Hour)
+	assert.Equal(t, now.Add(-time.Hour), first.WindowStart)
+	assert.Equal(t, now, first.WindowEnd)
+	assert.True(t, first.LastSuccessTime.IsZero())
+
+	lastSuccess := now.Add(-10 * time.Minute)
+	next := newTemplateVars(now, targetState{LastSuccessTime: lastSuccess, LastPollTime: now.Add(-time.Minute), LastCursor: "c1", LastID: "42"}, time.Hour)
+	assert.Equal(t, lastSuccess, next.WindowStart)
+	assert.Equal(t, now.Add(-time.Minute), next.LastPollTime)
+	assert.Equal(t, "c1", next.LastCursor)
+	assert.Equal(t, "42", next.LastID)
+}
+
+func TestRenderTemplate(t *testing.T) {
+	t.Setenv("LOGSRECEIVER_TOKEN", "s3cr3t")
+	vars := templateVars{
+		Now:         time.Date(2025, 10, 15, 12, 30, 0, 0, time.UTC),
+		WindowStart: time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC),
+		LastID:      "99",
+	}
+
+	tests := []struct {
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{text: "plain", want: "plain"},
+		{text: `{{ .WindowStart | format "2006-01-02" }}`, want: "2025-10-15"},
+		{text: `{{ unix .Now }}`, want: "1760531400"},
+		{text: `{{ unixMilli .WindowStart }}`, want: "1760529600000"},
+		{text: `{{ .Now | add "-1h" | format "15:04" }}`, want: "11:30"},
+		{text: `{{ .Now | utc | format "2006-01-02T15:04:05Z07:00" }}`, want: "2025-10-15T12:30:00Z"},
+		{text: `Bearer {{ env "LOGSRECEIVER_TOKEN" }}`, want: "Bearer s3cr3t"},
+		{text: `since_id={{ .LastID }}`, want: "since_id=99"},
+		{text: `{{ .Unknown }}`, wantErr: true},
+		{text: `{{ .Now | add "soon" }}`, wantErr: true},
+		{text: `{{ .Now `, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var got string
+		tmpl, err := parseTemplate("test", tt.text)
+		if err == nil {
+			got, err = renderTemplate(tmpl, tt.text, vars)
+		}
+		if tt.wantErr {
+			assert.Error(t, err, tt.text)
+			continue
+		}
+		require.NoError(t, err, tt.text)
+		assert.Equal(t, tt.want, got, tt.text)
+	}
+}
+
+func TestTargetConfig_ParsesTemplates(t *testing.T) {
+	target := &targetConfig{
+		ClientConfig: confighttp.ClientConfig{
+			Endpoint: "https://example.com/logs?from={{ unix .WindowStart }}",
+			Headers:  map[string]configopaque.String{"X-Since-ID": "{{ .LastID }}", "Accept": "application/json"},
+		},
+		Body: "static",
+	}
+	require.NoError(t, target.Validate())
+
+	// templates are parsed once at validation and only executed per request
+	assert.NotNil(t, target.endpointTemplate)
+	assert.Nil(t, target.bodyTemplate)
+	assert.Contains(t, target.headerTemplates, "X-Since-ID")
+	assert.NotContains(t, target.headerTemplates, "Accept")
+
+	target.Endpoint = "https://example.com/logs?from={{ .Now"
+	require.ErrorContains(t, target.Validate(), "invalid endpoint template")
+}
+
+func TestLogsReceiver_TemplatedRequest(t *testing.T) {
+	type request struct {
+		query  string
+		body   string
+		header string
+	}
+	var mu sync.Mutex
+	var requests []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, request{query: r.URL.RawQuery, body: string(body), header: r.Header.Get("X-Since-ID")})
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":7}]`))
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{
+		ClientConfig: confighttp.ClientConfig{
+			Endpoint: srv.URL + `/audit?from={{ unix .WindowStart }}`,
+			Headers:  map[string]configopaque.String{"X-Since-ID": "{{ .LastID }}"},
+		},
+		Method:  "POST",
+		Body:    `{"fromDate":"{{ .WindowStart | utc | format "2006-01-02T15:04:05Z" }}","toDate":"{{ .WindowEnd | utc | format "2006-01-02T15:04:05Z" }}"}`,
+		IDField: "id",
+	}
+	require.NoError(t, target.Validate())
+
+	settings := receivertest.NewNopSettings(component.MustNewType("logsreceiver"))
+	r := newLogsReceiver(&Config{CollectionInterval: time.Minute}, settings, &testLogsSink{})
+	ctx := context.Background()
+	require.NoError(t, r.pollTarget(ctx, target))
+	first, err := r.state.get(ctx, stateKey(target))
+	require.NoError(t, err)
+	require.NoError(t, r.pollTarget(ctx, target))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, requests, 2)
+
+	// The second poll's window starts where the first successful poll ended
+	windowStart := first.LastSuccessTime.UTC().Format("2006-01-02T15:04:05Z")
+	assert.Equal(t, "from="+strconv.FormatInt(first.LastSuccessTime.Unix(), 10), requests[1].query)
+	assert.Contains(t, requests[1].body, `"fromDate":"`+windowStart+`"`)
+	assert.Contains(t, requests[0].body, `"fromDate":"`+first.LastSuccessTime.Add(-time.Minute).UTC().Format("2006-01-02T15:04:05Z")+`"`)
+	assert.Empty(t, requests[0].header)
+	assert.Equal(t, "7", requests[1].header)
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// strptimeDirectives maps strptime directives to their Go layout equivalent.
+var strptimeDirectives = map[byte]string{
+	'Y': "2006",
+	'y': "06",
+	'm': "01",
+	'd': "02",
+	'e': "_2",
+	'j': "002",
+	'H': "15",
+	'I': "03",
+	'M': "04",
+	'S': "05",
+	'f': "000000",
+	'L': "000",
+	'p': "PM",
+	'b': "Jan",
+	'h': "Jan",
+	'B': "January",
+	'a': "Mon",
+	'A': "Monday",
+	'z': "-0700",
+	'Z': "MST",
+	'F': "2006-01-02",
+	'T': "15:04:05",
+	'D': "01/02/06",
+	'%': "%",
+}
+
+// strptimeToGoLayout converts a strptime-style layout into a Go time layout.
+func strptimeToGoLayout(layout string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(layout); i++ {
+		if layout[i] != '%' {
+			sb.WriteByte(layout[i])
+			continue
+		}
+
+		if i+1 >= len(layout) {
+			return "", fmt.Errorf("dangling %% at end of layout %q", layout)
+		}
+		i++
+
+		directive, ok := strptimeDirectives[layout[i]]
+		if !ok {
+			return "", fmt.Errorf("unsupported strptime directive %%%c", layout[i])
+		}
+		sb.WriteString(directive)
+	}
+
+	return sb.String(), nil
+}
+
+// parseTimestamp parses a raw field value decoded from JSON according to the timestamp config.
+func parseTimestamp(raw interface{}, cfg *timestampConfig) (time.Time, error) {
+	loc := cfg.location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	switch cfg.LayoutType {
+	case layoutTypeEpoch:
+		return parseEpoch(raw, cfg.Layout)
+	case layoutTypeStrptime:
+		layout := cfg.goLayout
+		if layout == "" {
+			var err error
+			if layout, err = strptimeToGoLayout(cfg.Layout); err != nil {
+				return time.Time{}, err
+			}
+		}
+		return time.ParseInLocation(layout, fmt.Sprintf("%v", raw), loc)
+	default:
+		layout := cfg.Layout
+		if layout == "" {
+			layout = time.RFC3339Nano
+		}
+		return time.ParseInLocation(layout, fmt.Sprintf("%v", raw), loc)
+	}
+}
+
+// parseEpoch parses a numeric or string epoch value in the given unit.
+func parseEpoch(raw interface{}, unit string) (time.Time, error) {
+	var value float64
+	switch v := raw.(type) {
+	case float64:
+		value = v
+	case json.Number:
+		return parseEpoch(string(v), unit)
+	case string:
+		// Integers are parsed exactly to keep nanosecond precision
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return epochFromInt(i, unit), nil
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid epoch value %q: %w", v, err)
+		}
+		value = f
+	default:
+		return time.Time{}, fmt.Errorf("invalid epoch value of type %T", raw)
+	}
+
+	// Split off the fraction so it is not lost to float rounding of the whole value
+	whole, frac := math.Modf(value)
+	var unitSize time.Duration
+	switch unit {
+	case "ms":
+		unitSize = time.Millisecond
+	case "us":
+		unitSize = time.Microsecond
+	case "ns":
+		unitSize = time.Nanosecond
+	default:
+		unitSize = time.Second
+	}
+
+	fraction := time.Duration(math.Round(frac * float64(unitSize)))
+	return epochFromInt(int64(whole), unit).Add(fraction), nil
+}
+
+// epochFromInt converts an integer epoch in the given unit to a time.
+func epochFromInt(value int64, unit string) time.Time {
+	switch unit {
+	case "ms":
+		return time.UnixMilli(value).UTC()
+	case "us":
+		return time.UnixMicro(value).UTC()
+	case "ns":
+		return time.Unix(0, value).UTC()
+	default:
+		return time.Unix(value, 0).UTC()
+	}
+}
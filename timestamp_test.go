@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrptimeToGoLayout(t *testing.T) {
+	layout, err := strptimeToGoLayout("%Y-%m-%d %H:%M:%S.%f %z")
+	require.NoError(t, err)
+	assert.Equal(t, "2006-01-02 15:04:05.000000 -0700", layout)
+
+	_, err = strptimeToGoLayout("%Q")
+	require.Error(t, err)
+
+	_, err = strptimeToGoLayout("%Y%")
+	require.Error(t, err)
+}
+
+func TestParseTimestamp(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		raw     interface{}
+		cfg     timestampConfig
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name: "rfc3339 default",
+			raw:  "2025-10-15T10:20:30.5Z",
+			cfg:  timestampConfig{LayoutType: layoutTypeGoTime},
+			want: time.Date(2025, 10, 15, 10, 20, 30, 500000000, time.UTC),
+		},
+		{
+			name: "go layout with location",
+			raw:  "2025-10-15 10:20:30",
+			cfg:  timestampConfig{LayoutType: layoutTypeGoTime, Layout: "2006-01-02 15:04:05", location: berlin},
+			want: time.Date(2025, 10, 15, 8, 20, 30, 0, time.UTC),
+		},
+		{
+			name: "strptime",
+			raw:  "15/Oct/2025:10:20:30 +0200",
+			cfg:  timestampConfig{LayoutType: layoutTypeStrptime, Layout: "%d/%b/%Y:%H:%M:%S %z"},
+			want: time.Date(2025, 10, 15, 8, 20, 30, 0, time.UTC),
+		},
+		{
+			name: "epoch seconds number",
+			raw:  float64(1760523630),
+			cfg:  timestampConfig{LayoutType: layoutTypeEpoch, Layout: "s"},
+			want: time.Unix(1760523630, 0),
+		},
+		{
+			name: "epoch fractional seconds",
+			raw:  1760523630.25,
+			cfg:  timestampConfig{LayoutType: layoutTypeEpoch, Layout: "s"},
+			want: time.Unix(1760523630, 250000000),
+		},
+		{
+			name: "epoch milliseconds number",
+			raw:  float64(1760523630123),
+			cfg:  timestampConfig{LayoutType: layoutTypeEpoch, Layout: "ms"},
+			want: time.UnixMilli(1760523630123),
+		},
+		{
+			name: "epoch microseconds string",
+			raw:  "1760523630123456",
+			cfg:  timestampConfig{LayoutType: layoutTypeEpoch, Layout: "us"},
+			want: time.UnixMicro(1760523630123456),
+		},
+		{
+			name: "epoch nanoseconds string",
+			raw:  "1760523630123456789",
+			cfg:  timestampConfig{LayoutType: layoutTypeEpoch, Layout: "ns"},
+			want: time.Unix(0, 1760523630123456789),
+		},
+		{
+			name: "epoch nanoseconds json number",
+			raw:  json.Number("1760523630123456789"),
+			cfg:  timestampConfig{LayoutType: layoutTypeEpoch, Layout: "ns"},
+			want: time.Unix(0, 1760523630123456789),
+		},
+		{
+			name: "epoch fractional json number",
+			raw:  json.Number("1760523630.25"),
+			cfg:  timestampConfig{LayoutType: layoutTypeEpoch, Layout: "s"},
+			want: time.Unix(1760523630, 250000000),
+		},
+		{
+			name:    "epoch invalid",
+			raw:     "yesterday",
+			cfg:     timestampConfig{LayoutType: layoutTypeEpoch, Layout: "s"},
+			wantErr: true,
+		},
+		{
+			name:    "layout mismatch",
+			raw:     "not a time",
+			cfg:     timestampConfig{LayoutType: layoutTypeGoTime},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTimestamp(tt.raw, &tt.cfg)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "want %v got %v", tt.want, got)
+		})
+	}
+}
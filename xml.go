@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/logsreceiver"
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// XML conversion keys for attributes and for the text of elements that also
+// have attributes or child elements
+const (
+	xmlAttributePrefix = "@"
+	xmlTextKey         = "#text"
+)
+
+// parseXMLLogs parses XML, where every element at the record path becomes a
+// log record. Without a record path, every child element of the root
+// element is a record. Elements are matched by their local names, so
+// namespace prefixes are ignored.
+func (r *logsReceiver) parseXMLLogs(body []byte, target *targetConfig, pollTime time.Time, logs plog.Logs, marks *recordMarks) (plog.Logs, error) {
+	var recordPath []string
+	if target.XML != nil {
+		recordPath = target.XML.recordPath
+	}
+
+	scopeLogs := appendTargetScopeLogs(logs, target)
+
+	dec := xml.NewDecoder(bytes.NewReader(body))
+	var path []string
+	for {
+		token, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return plog.Logs{}, fmt.Errorf("failed to decode XML: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			path = append(path, t.Name.Local)
+			if (recordPath == nil && len(path) == 2) || slices.Equal(path, recordPath) {
+				record, err := decodeXMLElement(dec, t)
+				if err != nil {
+					return plog.Logs{}, fmt.Errorf("failed to decode XML: %w", err)
+				}
+				r.addLogRecord(scopeLogs, record, nil, pollTime, target, marks)
+				path = path[:len(path)-1]
+			}
+		case xml.EndElement:
+			path = path[:len(path)-1]
+		}
+	}
+
+	return logs, nil
+}
+
+// decodeXMLElement converts the element opened by start into a map. Attributes
+// are keyed by their name prefixed with @, child elements by their name, and
+// repeated child elements are collected into a list. An element with nothing
+// but text becomes a string; otherwise its text is kept under #text.
+func decodeXMLElement(dec *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	node := map[string]interface{}{}
+	for _, attr := range start.Attr {
+		// Namespace declarations are not data
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		node[xmlAttributePrefix+attr.Name.Local] = attr.Value
+	}
+
+	var (
+		text     strings.Builder
+		names    []string
+		children = map[string][]interface{}{}
+	)
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(dec, t)
+			if err != nil {
+				return nil, err
+			}
+			if _, seen := children[t.Name.Local]; !seen {
+				names = append(names, t.Name.Local)
+			}
+			children[t.Name.Local] = append(children[t.Name.Local], child)
+
+		case xml.CharData:
+			text.Write(t)
+
+		case xml.EndElement:
+			content := strings.TrimSpace(text.String())
+			if len(node) == 0 && len(names) == 0 {
+				return content, nil
+			}
+
+			for _, name := range names {
+				if values := children[name]; len(values) == 1 {
+					node[name] = values[0]
+				} else {
+					node[name] = values
+				}
+			}
+			if content != "" {
+				node[xmlTextKey] = content
+			}
+			return node, nil
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logsreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+const soapAudit = `<?xml version="1.0" encoding="UTF-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:a="urn:audit">
+  <soap:Header><a:Session>42</a:Session></soap:Header>
+  <soap:Body>
+    <a:Events count="2">
+      <a:Event id="1" type="login">
+        <a:User email="alice@example.com">alice</a:User>
+        <a:Tag>web</a:Tag>
+        <a:Tag>mfa</a:Tag>
+        <a:Message>Signed in</a:Message>
+      </a:Event>
+      <a:Event id="2" type="logout">
+        <a:User email="bob@example.com">bob</a:User>
+        <a:Details/>
+      </a:Event>
+    </a:Events>
+  </soap:Body>
+</soap:Envelope>`
+
+func TestLogsReceiver_XML(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+		_, _ = w.Write([]byte(soapAudit))
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{
+		ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL},
+		XML:          &xmlConfig{RecordPath: "/Envelope/Body/Events/Event"},
+		Labels:       map[string]string{"email": "User.@email", "type": "@type"},
+	}
+	require.NoError(t, target.Validate())
+
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), nil)
+	bodies, attrs := pollBodies(t, r, target)
+
+	assert.Equal(t, []map[string]interface{}{
+		{
+			"@id":     "1",
+			"@type":   "login",
+			"User":    map[string]interface{}{"@email": "alice@example.com", "#text": "alice"},
+			"Tag":     []interface{}{"web", "mfa"},
+			"Message": "Signed in",
+		},
+		{
+			"@id":     "2",
+			"@type":   "logout",
+			"User":    map[string]interface{}{"@email": "bob@example.com", "#text": "bob"},
+			"Details": "",
+		},
+	}, bodies)
+	assert.Equal(t, []map[string]interface{}{
+		{"email": "alice@example.com", "type": "login"},
+		{"email": "bob@example.com", "type": "logout"},
+	}, attrs)
+}
+
+func TestLogsReceiver_XMLDefaultRecordPath(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// sniffed, as the content type is generic
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte(`<Events><Event><Id>1</Id></Event><!-- note --><Event><Id>2</Id></Event></Events>`))
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}}
+	require.NoError(t, target.Validate())
+
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), nil)
+	bodies, _ := pollBodies(t, r, target)
+	assert.Equal(t, []map[string]interface{}{{"Id": "1"}, {"Id": "2"}}, bodies)
+}
+
+func TestLogsReceiver_XMLMalformed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(`<Events><Event><Id>1</Event></Events>`))
+	}))
+	defer srv.Close()
+
+	target := &targetConfig{ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL}}
+	require.NoError(t, target.Validate())
+
+	r := newLogsReceiver(&Config{}, receivertest.NewNopSettings(component.MustNewType("logsreceiver")), &testLogsSink{})
+	require.ErrorContains(t, r.pollTarget(context.Background(), target), "failed to decode XML")
+}